@@ -0,0 +1,741 @@
+//go:build all || cpdp
+
+package bgp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+/* Demonstrates spec-correct BGP OPEN negotiation and failure handling between
+   two emulated IPv4 peers, configured with mismatched capabilities, AS
+   numbers, hold times and AS number widths, or with keepalives of a peer
+   dropped.
+   Each test asserts on the resulting session state, flap and notification
+   counters reported in BGPv4 metrics, and where applicable, on learnt routes. */
+
+func bgpSessionTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"txMac":        "00:00:01:01:01:01",
+		"txIp":         "1.1.1.1",
+		"txGateway":    "1.1.1.2",
+		"txPrefix":     uint32(24),
+		"txAs":         uint32(1111),
+		"txAsType":     gosnappi.BgpV4PeerAsType.EBGP,
+		"txAsWidth":    gosnappi.BgpV4PeerAsNumberWidth.FOUR,
+		"txHoldTime":   uint32(90),
+		"txKeepAlive":  uint32(30),
+		"txIpv6Cap":    true,
+		"rxMac":        "00:00:01:01:01:02",
+		"rxIp":         "1.1.1.2",
+		"rxGateway":    "1.1.1.1",
+		"rxPrefix":     uint32(24),
+		"rxAs":         uint32(1112),
+		"rxAsType":     gosnappi.BgpV4PeerAsType.EBGP,
+		"rxAsWidth":    gosnappi.BgpV4PeerAsNumberWidth.FOUR,
+		"rxHoldTime":   uint32(90),
+		"rxKeepAlive":  uint32(30),
+		"rxIpv6Cap":    true,
+		"txRouteCount": uint32(5),
+		"txNextHopV4":  "1.1.1.3",
+		"txNextHopV6":  "::1:1:1:3",
+		"txAdvRouteV4": "10.10.10.1",
+		"txAdvRouteV6": "::10:10:10:1",
+	}
+}
+
+func TestBgpCapabilityMismatch(t *testing.T) {
+	testConst := bgpSessionTestConst()
+	testConst["rxIpv6Cap"] = false
+
+	api := otg.NewOtgApi(t)
+	c := bgpSessionConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	/* Check if sessions come up with only IPv4 unicast negotiated, i.e.
+	   IPv6 routes are not advertised to a peer lacking the capability */
+	api.WaitFor(
+		func() bool { return bgpCapabilityMismatchMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4Metrics"},
+	)
+
+	/* Check if receiving peer never learns IPv6 prefixes */
+	api.EnsureFor(
+		func() bool { return bgpCapabilityMismatchPrefixesOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "EnsureBgpRoutePrefixes", Timeout: 5 * time.Second},
+	)
+}
+
+func TestBgpPeerAsMismatch(t *testing.T) {
+	testConst := bgpSessionTestConst()
+	testConst["txAsType"] = gosnappi.BgpV4PeerAsType.IBGP
+	testConst["rxAsType"] = gosnappi.BgpV4PeerAsType.IBGP
+	testConst["rxAs"] = uint32(2222)
+
+	api := otg.NewOtgApi(t)
+	c := bgpSessionConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	/* Check if an OPEN with unexpected peer AS is rejected with a NOTIFICATION */
+	api.WaitFor(
+		func() bool { return bgpSessionNotifiedMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4Notifications", Timeout: 30 * time.Second},
+	)
+
+	/* Check if sessions never get established */
+	api.EnsureFor(
+		func() bool { return bgpSessionNeverUpMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "EnsureBgpv4SessionsDown", Timeout: 10 * time.Second},
+	)
+}
+
+func TestBgpUnacceptableHoldTime(t *testing.T) {
+	testConst := bgpSessionTestConst()
+	/* hold time of 1 or 2 seconds must be rejected as per RFC 4271 */
+	testConst["rxHoldTime"] = uint32(2)
+	testConst["rxKeepAlive"] = uint32(1)
+
+	api := otg.NewOtgApi(t)
+	c := bgpSessionConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	/* Check if an OPEN with unacceptable hold time is rejected with a NOTIFICATION */
+	api.WaitFor(
+		func() bool { return bgpSessionNotifiedMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4Notifications", Timeout: 30 * time.Second},
+	)
+
+	/* Check if sessions never get established */
+	api.EnsureFor(
+		func() bool { return bgpSessionNeverUpMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "EnsureBgpv4SessionsDown", Timeout: 10 * time.Second},
+	)
+}
+
+func TestBgpHoldTimeNegotiation(t *testing.T) {
+	testConst := bgpSessionTestConst()
+	testConst["txHoldTime"] = uint32(9)
+	testConst["txKeepAlive"] = uint32(3)
+
+	api := otg.NewOtgApi(t)
+	c := bgpSessionConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return bgpSessionUpMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4SessionsUp"},
+	)
+
+	/* Check if sessions stay up for several negotiated hold time intervals,
+	   i.e. the peer configured with larger hold time honours the smaller one */
+	api.EnsureFor(
+		func() bool { return bgpHoldTimeNegotiationMetricsOk(api) },
+		&otg.WaitForOpts{
+			FnName:   "EnsureBgpv4SessionsUp",
+			Interval: 1 * time.Second,
+			Timeout:  time.Duration(3*testConst["txHoldTime"].(uint32)) * time.Second,
+		},
+	)
+
+	/* Check if keepalives were received at least at the negotiated rate */
+	api.WaitFor(
+		func() bool { return bgpHoldTimeNegotiationKeepalivesOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4Keepalives"},
+	)
+}
+
+func TestBgpHoldTimerExpiry(t *testing.T) {
+	testConst := bgpSessionTestConst()
+	testConst["txHoldTime"] = uint32(9)
+	testConst["txKeepAlive"] = uint32(3)
+	testConst["rxHoldTime"] = uint32(9)
+	testConst["rxKeepAlive"] = uint32(3)
+	/* MAC not owned by any device, advertised by gratuitous ARPs for address
+	   of transmitting peer */
+	testConst["garpMac"] = "00:00:0a:0a:0a:0a"
+	testConst["garpRate"] = uint64(10)
+	/* allowance for metrics polling beyond negotiated hold time */
+	testConst["expirySlack"] = 3 * time.Second
+
+	api := otg.NewOtgApi(t)
+	c := bgpSessionConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return bgpSessionUpMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4SessionsUp"},
+	)
+
+	before := bgpSessionMetrics(api)
+	if _, ok := before["dtxBgpv4Peer"]; !ok {
+		t.Fatalf("ERROR: BGPv4 metrics of dtxBgpv4Peer not found\n")
+	}
+	if _, ok := before["drxBgpv4Peer"]; !ok {
+		t.Fatalf("ERROR: BGPv4 metrics of drxBgpv4Peer not found\n")
+	}
+
+	api.StartCapture()
+
+	/* Keep links up, but mislead receiving peer to send its packets to a MAC
+	   not owned by transmitting peer, so that its keepalives are dropped */
+	api.StartTransmit()
+
+	/* Check if transmitting peer goes down within negotiated hold time and
+	   notifies receiving peer, which still reaches it */
+	api.WaitFor(
+		func() bool { return bgpHoldTimerExpiryMetricsOk(api, before) },
+		&otg.WaitForOpts{
+			FnName:   "WaitForBgpv4HoldTimerExpiry",
+			Interval: 1 * time.Second,
+			Timeout:  time.Duration(testConst["txHoldTime"].(uint32))*time.Second + testConst["expirySlack"].(time.Duration),
+		},
+	)
+
+	/* Check if links stayed up, i.e. session went down on hold timer expiry alone */
+	linksUp := map[string]gosnappi.PortMetricLinkEnum{
+		"ptx": gosnappi.PortMetricLink.UP,
+		"prx": gosnappi.PortMetricLink.UP,
+	}
+	if !api.PortLinksOk(linksUp) {
+		t.Fatalf("ERROR: Port links went down during hold timer expiry\n")
+	}
+
+	api.StopTransmit()
+	api.StopCapture()
+
+	bgpHoldTimerExpiryCaptureOk(api, c, testConst)
+}
+
+func TestBgpNotification(t *testing.T) {
+	testConst := bgpSessionTestConst()
+
+	api := otg.NewOtgApi(t)
+	c := bgpSessionConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return bgpSessionUpMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4SessionsUp"},
+	)
+
+	/* Administratively stop receiving peer, which shall send a Cease NOTIFICATION */
+	api.StopBgpPeers([]string{"drxBgpv4Peer"})
+
+	api.WaitFor(
+		func() bool { return bgpNotificationPeerDownMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4PeerDown"},
+	)
+
+	api.StartBgpPeers([]string{"drxBgpv4Peer"})
+
+	/* Check if session is re-established and flap is accounted for */
+	api.WaitFor(
+		func() bool { return bgpNotificationPeerUpMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4PeerUp", Timeout: 30 * time.Second},
+	)
+}
+
+func TestBgpLinkDown(t *testing.T) {
+	testConst := bgpSessionTestConst()
+	/* short hold time bounds detection of link loss by peers which do not
+	   see their own link go down */
+	testConst["txHoldTime"] = uint32(9)
+	testConst["txKeepAlive"] = uint32(3)
+	testConst["rxHoldTime"] = uint32(9)
+	testConst["rxKeepAlive"] = uint32(3)
+
+	api := otg.NewOtgApi(t)
+	c := bgpSessionConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return bgpSessionUpMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4SessionsUp"},
+	)
+
+	api.SetPortLinksDown([]string{"ptx"})
+
+	/* Check if both sessions go down once link of transmitting peer is lost */
+	api.WaitFor(
+		func() bool { return bgpLinkDownMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4SessionsDown", Timeout: 30 * time.Second},
+	)
+
+	api.SetPortLinksUp([]string{"ptx"})
+
+	/* Check if session is re-established and flap is accounted for */
+	api.WaitFor(
+		func() bool { return bgpNotificationPeerUpMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4PeerUp", Timeout: 60 * time.Second},
+	)
+}
+
+func TestBgpFourByteAs(t *testing.T) {
+	testConst := bgpSessionTestConst()
+	testConst["txAs"] = uint32(4200000001)
+
+	api := otg.NewOtgApi(t)
+	c := bgpSessionConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return bgpSessionUpMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4SessionsUp"},
+	)
+
+	/* Check if 4-byte AS is carried as is in AS path of learnt routes */
+	api.WaitFor(
+		func() bool {
+			return bgpFourByteAsPrefixesOk(api, testConst, []uint32{testConst["txAs"].(uint32)})
+		},
+		&otg.WaitForOpts{FnName: "WaitForBgpRoutePrefixes"},
+	)
+}
+
+func TestBgpFourByteAsToTwoByteAsPeer(t *testing.T) {
+	testConst := bgpSessionTestConst()
+	testConst["txAs"] = uint32(4200000001)
+	testConst["rxAs"] = uint32(65002)
+	testConst["rxAsWidth"] = gosnappi.BgpV4PeerAsNumberWidth.TWO
+
+	api := otg.NewOtgApi(t)
+	c := bgpSessionConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	/* Check if session comes up using AS_TRANS in OPEN towards 2-byte AS peer */
+	api.WaitFor(
+		func() bool { return bgpSessionUpMetricsOk(api) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4SessionsUp"},
+	)
+
+	/* Check if AS path of learnt routes starts with either AS_TRANS (23456)
+	   or the actual 4-byte AS reconstructed from AS4_PATH */
+	api.WaitFor(
+		func() bool {
+			return bgpFourByteAsPrefixesOk(api, testConst, []uint32{testConst["txAs"].(uint32), 23456})
+		},
+		&otg.WaitForOpts{FnName: "WaitForBgpRoutePrefixes"},
+	)
+}
+
+func bgpSessionConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	dtx := c.Devices().Add().SetName("dtx")
+	drx := c.Devices().Add().SetName("drx")
+
+	dtxEth := dtx.Ethernets().
+		Add().
+		SetName("dtxEth").
+		SetMac(tc["txMac"].(string)).
+		SetMtu(1500)
+
+	dtxEth.Connection().SetPortName(ptx.Name())
+
+	dtxIp := dtxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("dtxIp").
+		SetAddress(tc["txIp"].(string)).
+		SetGateway(tc["txGateway"].(string)).
+		SetPrefix(tc["txPrefix"].(uint32))
+
+	dtxBgp := dtx.Bgp().
+		SetRouterId(tc["txIp"].(string))
+
+	dtxBgpv4 := dtxBgp.
+		Ipv4Interfaces().Add().
+		SetIpv4Name(dtxIp.Name())
+
+	dtxBgpv4Peer := dtxBgpv4.
+		Peers().
+		Add().
+		SetAsNumber(tc["txAs"].(uint32)).
+		SetAsType(tc["txAsType"].(gosnappi.BgpV4PeerAsTypeEnum)).
+		SetAsNumberWidth(tc["txAsWidth"].(gosnappi.BgpV4PeerAsNumberWidthEnum)).
+		SetPeerAddress(tc["txGateway"].(string)).
+		SetName("dtxBgpv4Peer")
+
+	dtxBgpv4Peer.Advanced().
+		SetHoldTimeInterval(tc["txHoldTime"].(uint32)).
+		SetKeepAliveInterval(tc["txKeepAlive"].(uint32))
+
+	dtxBgpv4Peer.Capability().
+		SetIpv4Unicast(true).
+		SetIpv6Unicast(tc["txIpv6Cap"].(bool))
+
+	dtxBgpv4PeerRrV4 := dtxBgpv4Peer.
+		V4Routes().
+		Add().
+		SetName("dtxBgpv4PeerRrV4").
+		SetNextHopIpv4Address(tc["txNextHopV4"].(string)).
+		SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+		SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+
+	dtxBgpv4PeerRrV4.Addresses().Add().
+		SetAddress(tc["txAdvRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(tc["txRouteCount"].(uint32)).
+		SetStep(1)
+
+	dtxBgpv4PeerRrV4.AsPath().SetAsSetMode(gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SEQ)
+
+	dtxBgpv4PeerRrV6 := dtxBgpv4Peer.
+		V6Routes().
+		Add().
+		SetName("dtxBgpv4PeerRrV6").
+		SetNextHopIpv6Address(tc["txNextHopV6"].(string)).
+		SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.MANUAL)
+
+	dtxBgpv4PeerRrV6.Addresses().Add().
+		SetAddress(tc["txAdvRouteV6"].(string)).
+		SetPrefix(128).
+		SetCount(tc["txRouteCount"].(uint32)).
+		SetStep(1)
+
+	dtxBgpv4PeerRrV6.AsPath().SetAsSetMode(gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SEQ)
+
+	drxEth := drx.Ethernets().
+		Add().
+		SetName("drxEth").
+		SetMac(tc["rxMac"].(string)).
+		SetMtu(1500)
+
+	drxEth.Connection().SetPortName(prx.Name())
+
+	drxIp := drxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("drxIp").
+		SetAddress(tc["rxIp"].(string)).
+		SetGateway(tc["rxGateway"].(string)).
+		SetPrefix(tc["rxPrefix"].(uint32))
+
+	drxBgp := drx.Bgp().
+		SetRouterId(tc["rxIp"].(string))
+
+	drxBgpv4 := drxBgp.
+		Ipv4Interfaces().Add().
+		SetIpv4Name(drxIp.Name())
+
+	drxBgpv4Peer := drxBgpv4.
+		Peers().
+		Add().
+		SetAsNumber(tc["rxAs"].(uint32)).
+		SetAsType(tc["rxAsType"].(gosnappi.BgpV4PeerAsTypeEnum)).
+		SetAsNumberWidth(tc["rxAsWidth"].(gosnappi.BgpV4PeerAsNumberWidthEnum)).
+		SetPeerAddress(tc["rxGateway"].(string)).
+		SetName("drxBgpv4Peer")
+
+	drxBgpv4Peer.Advanced().
+		SetHoldTimeInterval(tc["rxHoldTime"].(uint32)).
+		SetKeepAliveInterval(tc["rxKeepAlive"].(uint32))
+
+	drxBgpv4Peer.Capability().
+		SetIpv4Unicast(true).
+		SetIpv6Unicast(tc["rxIpv6Cap"].(bool))
+
+	drxBgpv4Peer.LearnedInformationFilter().SetUnicastIpv4Prefix(true).SetUnicastIpv6Prefix(true)
+
+	if garpMac, ok := tc["garpMac"]; ok {
+		if api.TestConfig().OtgCaptureCheck {
+			c.Captures().Add().
+				SetName("ca").
+				SetPortNames([]string{prx.Name()}).
+				SetFormat(gosnappi.CaptureFormat.PCAP)
+		}
+
+		/* ARP replies claiming address of transmitting peer for garpMac,
+		   which overwrite ARP entry of receiving peer for its gateway */
+		fGarp := c.Flows().Add().SetName("fGarp")
+		fGarp.TxRx().Port().
+			SetTxName(ptx.Name()).
+			SetRxNames([]string{prx.Name()})
+		fGarp.Duration().Continuous()
+		fGarp.Rate().SetPps(tc["garpRate"].(uint64))
+
+		fGarpEth := fGarp.Packet().Add().Ethernet()
+		fGarpEth.Src().SetValue(garpMac.(string))
+		fGarpEth.Dst().SetValue(tc["rxMac"].(string))
+
+		fGarpArp := fGarp.Packet().Add().Arp()
+		fGarpArp.Operation().SetValue(2)
+		fGarpArp.SenderHardwareAddr().SetValue(garpMac.(string))
+		fGarpArp.SenderProtocolAddr().SetValue(tc["txIp"].(string))
+		fGarpArp.TargetHardwareAddr().SetValue(tc["rxMac"].(string))
+		fGarpArp.TargetProtocolAddr().SetValue(tc["rxIp"].(string))
+	}
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func bgpSessionMetrics(api *otg.OtgApi) map[string]gosnappi.Bgpv4Metric {
+	metrics := map[string]gosnappi.Bgpv4Metric{}
+	for _, m := range api.GetBgpv4Metrics() {
+		metrics[m.Name()] = m
+	}
+	return metrics
+}
+
+func bgpSessionUpMetricsOk(api *otg.OtgApi) bool {
+	for _, m := range api.GetBgpv4Metrics() {
+		if m.SessionState() == gosnappi.Bgpv4MetricSessionState.DOWN {
+			return false
+		}
+	}
+	return true
+}
+
+func bgpSessionNotifiedMetricsOk(api *otg.OtgApi) bool {
+	notifications := uint64(0)
+	for _, m := range api.GetBgpv4Metrics() {
+		if m.SessionState() == gosnappi.Bgpv4MetricSessionState.UP {
+			return false
+		}
+		notifications += m.NotificationsSent() + m.NotificationsReceived()
+	}
+	return notifications > 0
+}
+
+func bgpSessionNeverUpMetricsOk(api *otg.OtgApi) bool {
+	for _, m := range api.GetBgpv4Metrics() {
+		if m.SessionState() == gosnappi.Bgpv4MetricSessionState.UP ||
+			m.FsmState() == gosnappi.Bgpv4MetricFsmState.ESTABLISHED ||
+			m.SessionFlapCount() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func bgpCapabilityMismatchMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	metrics := bgpSessionMetrics(api)
+	dtx, ok1 := metrics["dtxBgpv4Peer"]
+	drx, ok2 := metrics["drxBgpv4Peer"]
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	routeCount := uint64(tc["txRouteCount"].(uint32))
+	return dtx.SessionState() == gosnappi.Bgpv4MetricSessionState.UP &&
+		drx.SessionState() == gosnappi.Bgpv4MetricSessionState.UP &&
+		dtx.RoutesAdvertised() == routeCount &&
+		drx.RoutesReceived() == routeCount &&
+		dtx.NotificationsSent() == 0 &&
+		drx.NotificationsSent() == 0
+}
+
+func bgpCapabilityMismatchPrefixesOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	prefixCount := 0
+	for _, m := range api.GetBgpPrefixes() {
+		if m.BgpPeerName() != "drxBgpv4Peer" {
+			continue
+		}
+		if len(m.Ipv6UnicastPrefixes().Items()) != 0 {
+			api.Testing().Logf("Unexpected IPv6 prefixes learnt by %s\n", m.BgpPeerName())
+			return false
+		}
+		prefixCount += len(m.Ipv4UnicastPrefixes().Items())
+	}
+	return prefixCount == int(tc["txRouteCount"].(uint32))
+}
+
+func bgpHoldTimeNegotiationMetricsOk(api *otg.OtgApi) bool {
+	for _, m := range api.GetBgpv4Metrics() {
+		if m.SessionState() == gosnappi.Bgpv4MetricSessionState.DOWN ||
+			m.SessionFlapCount() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func bgpHoldTimeNegotiationKeepalivesOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	m, ok := bgpSessionMetrics(api)["dtxBgpv4Peer"]
+	if !ok {
+		return false
+	}
+	/* ensure duration spans 3 hold time intervals, with keepalives expected
+	   at least once every negotiated keepalive interval */
+	return m.KeepalivesReceived() >= uint64(3*tc["txHoldTime"].(uint32)/tc["txKeepAlive"].(uint32))
+}
+
+func bgpNotificationPeerDownMetricsOk(api *otg.OtgApi) bool {
+	metrics := bgpSessionMetrics(api)
+	dtx, ok1 := metrics["dtxBgpv4Peer"]
+	drx, ok2 := metrics["drxBgpv4Peer"]
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	return dtx.SessionState() == gosnappi.Bgpv4MetricSessionState.DOWN &&
+		dtx.NotificationsReceived() >= 1 &&
+		drx.NotificationsSent() >= 1
+}
+
+func bgpNotificationPeerUpMetricsOk(api *otg.OtgApi) bool {
+	metrics := bgpSessionMetrics(api)
+	dtx, ok1 := metrics["dtxBgpv4Peer"]
+	drx, ok2 := metrics["drxBgpv4Peer"]
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	return dtx.SessionState() == gosnappi.Bgpv4MetricSessionState.UP &&
+		drx.SessionState() == gosnappi.Bgpv4MetricSessionState.UP &&
+		dtx.SessionFlapCount() >= 1
+}
+
+func bgpHoldTimerExpiryMetricsOk(api *otg.OtgApi, before map[string]gosnappi.Bgpv4Metric) bool {
+	metrics := bgpSessionMetrics(api)
+	dtx, ok1 := metrics["dtxBgpv4Peer"]
+	drx, ok2 := metrics["drxBgpv4Peer"]
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	return dtx.SessionState() == gosnappi.Bgpv4MetricSessionState.DOWN &&
+		dtx.SessionFlapCount() > before["dtxBgpv4Peer"].SessionFlapCount() &&
+		dtx.NotificationsSent() > before["dtxBgpv4Peer"].NotificationsSent() &&
+		drx.NotificationsReceived() > before["drxBgpv4Peer"].NotificationsReceived()
+}
+
+func bgpHoldTimerExpiryCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]interface{}) {
+	if !api.TestConfig().OtgCaptureCheck {
+		return
+	}
+	t := api.Testing()
+	cPackets := api.GetCapture(c.Ports().Items()[1].Name())
+	txIp := api.Ipv4AddrToBytes(tc["txIp"].(string))
+
+	for _, p := range cPackets.Packets {
+		code, ok := bgpNotificationErrorCode(p.Data, txIp)
+		if !ok {
+			continue
+		}
+		/* error code 4 as per RFC 4271 */
+		if code != 4 {
+			t.Fatalf("ERROR: BGP NOTIFICATION from %s has error code %d != 4 (Hold Timer Expired)\n", tc["txIp"].(string), code)
+		}
+		return
+	}
+	t.Fatalf("ERROR: No BGP NOTIFICATION from %s captured\n", tc["txIp"].(string))
+}
+
+// bgpNotificationErrorCode returns error code of first BGP NOTIFICATION
+// carried in frame data, if it is a TCP segment to or from port 179 sent by
+// IPv4 address src
+func bgpNotificationErrorCode(data []byte, src []byte) (uint8, bool) {
+	if len(data) < 34 || binary.BigEndian.Uint16(data[12:]) != 0x0800 ||
+		data[23] != 6 || !bytes.Equal(data[26:30], src) {
+		return 0, false
+	}
+	end := 14 + int(binary.BigEndian.Uint16(data[16:]))
+	tcp := 14 + int(data[14]&0x0f)*4
+	if end > len(data) || tcp+20 > end {
+		return 0, false
+	}
+	if binary.BigEndian.Uint16(data[tcp:]) != 179 && binary.BigEndian.Uint16(data[tcp+2:]) != 179 {
+		return 0, false
+	}
+
+	/* segment may carry several messages, each with 16 byte marker, 2 byte
+	   length and 1 byte type, where type 3 is NOTIFICATION */
+	for off := tcp + int(data[tcp+12]>>4)*4; off+19 <= end; {
+		length := int(binary.BigEndian.Uint16(data[off+16:]))
+		if length < 19 || off+length > end {
+			return 0, false
+		}
+		if data[off+18] == 3 && length >= 21 {
+			return data[off+19], true
+		}
+		off += length
+	}
+	return 0, false
+}
+
+func bgpLinkDownMetricsOk(api *otg.OtgApi) bool {
+	metrics := bgpSessionMetrics(api)
+	dtx, ok1 := metrics["dtxBgpv4Peer"]
+	drx, ok2 := metrics["drxBgpv4Peer"]
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	return dtx.SessionState() == gosnappi.Bgpv4MetricSessionState.DOWN &&
+		drx.SessionState() == gosnappi.Bgpv4MetricSessionState.DOWN
+}
+
+func bgpFourByteAsPrefixesOk(api *otg.OtgApi, tc map[string]interface{}, firstAs []uint32) bool {
+	prefixCount := 0
+	for _, m := range api.GetBgpPrefixes() {
+		if m.BgpPeerName() != "drxBgpv4Peer" {
+			continue
+		}
+		for _, p := range m.Ipv4UnicastPrefixes().Items() {
+			asPath := []uint32{}
+			for _, s := range p.AsPath().Segments().Items() {
+				asPath = append(asPath, s.AsNumbers()...)
+			}
+			if len(asPath) == 0 {
+				api.Testing().Logf("Empty AS path for %s\n", p.Ipv4Address())
+				return false
+			}
+
+			found := false
+			for _, as := range firstAs {
+				if asPath[0] == as {
+					found = true
+				}
+			}
+			if !found {
+				api.Testing().Logf("Unexpected AS path %v for %s\n", asPath, p.Ipv4Address())
+				return false
+			}
+			prefixCount += 1
+		}
+	}
+	return prefixCount == int(tc["txRouteCount"].(uint32))
+}
@@ -15,7 +15,6 @@ import (
    used to verify Local Preference behaviour */
 
 func TestEbgpRoutePrefix(t *testing.T) {
-	// TODO: add support for BGP for IPv6 as well
 	testConst := map[string]interface{}{
 		"pktRate":      uint64(50),
 		"pktCount":     uint32(100),
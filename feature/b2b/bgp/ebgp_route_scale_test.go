@@ -0,0 +1,217 @@
+//go:build all || cpdp
+
+package bgp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+/* Demonstrates advertisement of an internet-table-like set of IPv4 and IPv6
+   routes generated from a compact spec, with varying prefix lengths, AS paths,
+   communities and MED, and validates that the receiving peer learnt every
+   generated prefix with matching attributes. */
+
+func TestEbgpRouteScale(t *testing.T) {
+	testConst := map[string]interface{}{
+		"txMac":          "00:00:01:01:01:01",
+		"txIp":           "1.1.1.1",
+		"txGateway":      "1.1.1.2",
+		"txPrefix":       uint32(24),
+		"txAs":           uint32(1111),
+		"rxMac":          "00:00:01:01:01:02",
+		"rxIp":           "1.1.1.2",
+		"rxGateway":      "1.1.1.1",
+		"rxPrefix":       uint32(24),
+		"rxAs":           uint32(1112),
+		"rangeCount":     25,
+		"routesPerRange": uint32(20),
+		"txNextHopV4":    "1.1.1.3",
+		"txNextHopV6":    "::1:1:1:3",
+		"txAdvRouteV4":   "16.0.0.0",
+		"txAdvRouteV6":   "2001:db8::",
+		"seed":           int64(1),
+	}
+
+	api := otg.NewOtgApi(t)
+
+	v4Scale, v6Scale := ebgpRouteScaleRoutes(api, testConst)
+	c := ebgpRouteScaleConfig(api, testConst, v4Scale, v6Scale)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	/* Check if BGP sessions are up and all generated routes are Txed and Rxed */
+	api.WaitFor(
+		func() bool { return ebgpRouteScaleBgpMetricsOk(api, v4Scale, v6Scale) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv4Metrics", Timeout: 60 * time.Second},
+	)
+
+	/* Check if receiving peer learnt every generated prefix with expected attributes */
+	api.WaitFor(
+		func() bool { return ebgpRouteScaleBgpPrefixesOk(api, v4Scale, v6Scale) },
+		&otg.WaitForOpts{FnName: "WaitForBgpRoutePrefixes", Timeout: 60 * time.Second},
+	)
+}
+
+func ebgpRouteScaleRoutes(api *otg.OtgApi, tc map[string]interface{}) (*otg.BgpRouteScale, *otg.BgpRouteScale) {
+	asPathPool := []uint32{174, 1299, 2914, 3257, 3356, 6453, 6762, 6939, 7018}
+	communityPool := []otg.BgpCommunityValue{
+		{AsNumber: 1111, AsCustom: 100},
+		{AsNumber: 1111, AsCustom: 200},
+		{AsNumber: 1111, AsCustom: 300},
+		{AsNumber: 65000, AsCustom: 1},
+		{AsNumber: 65000, AsCustom: 2},
+	}
+
+	v4Scale := api.NewBgpRouteScale(otg.BgpRouteScaleSpec{
+		Name:           "dtxBgpv4PeerRrV4",
+		StartAddress:   tc["txAdvRouteV4"].(string),
+		RangeCount:     tc["rangeCount"].(int),
+		RoutesPerRange: tc["routesPerRange"].(uint32),
+		PrefixLengths:  otg.BgpInternetV4PrefixLengths,
+		NextHop:        tc["txNextHopV4"].(string),
+		LocalAs:        tc["txAs"].(uint32),
+		AsPathMinLen:   1,
+		AsPathMaxLen:   6,
+		AsPathPool:     asPathPool,
+		CommunityPool:  communityPool,
+		MaxCommunities: 3,
+		MedMin:         0,
+		MedMax:         200,
+		Seed:           tc["seed"].(int64),
+	})
+
+	v6Scale := api.NewBgpRouteScale(otg.BgpRouteScaleSpec{
+		Name:           "dtxBgpv4PeerRrV6",
+		StartAddress:   tc["txAdvRouteV6"].(string),
+		RangeCount:     tc["rangeCount"].(int),
+		RoutesPerRange: tc["routesPerRange"].(uint32),
+		PrefixLengths:  otg.BgpInternetV6PrefixLengths,
+		NextHop:        tc["txNextHopV6"].(string),
+		LocalAs:        tc["txAs"].(uint32),
+		AsPathMinLen:   1,
+		AsPathMaxLen:   6,
+		AsPathPool:     asPathPool,
+		CommunityPool:  communityPool,
+		MaxCommunities: 3,
+		MedMin:         0,
+		MedMax:         200,
+		Seed:           tc["seed"].(int64) + 1,
+	})
+
+	return v4Scale, v6Scale
+}
+
+func ebgpRouteScaleConfig(api *otg.OtgApi, tc map[string]interface{}, v4Scale *otg.BgpRouteScale, v6Scale *otg.BgpRouteScale) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	dtx := c.Devices().Add().SetName("dtx")
+	drx := c.Devices().Add().SetName("drx")
+
+	dtxEth := dtx.Ethernets().
+		Add().
+		SetName("dtxEth").
+		SetMac(tc["txMac"].(string)).
+		SetMtu(1500)
+
+	dtxEth.Connection().SetPortName(ptx.Name())
+
+	dtxIp := dtxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("dtxIp").
+		SetAddress(tc["txIp"].(string)).
+		SetGateway(tc["txGateway"].(string)).
+		SetPrefix(tc["txPrefix"].(uint32))
+
+	dtxBgp := dtx.Bgp().
+		SetRouterId(tc["txIp"].(string))
+
+	dtxBgpv4 := dtxBgp.
+		Ipv4Interfaces().Add().
+		SetIpv4Name(dtxIp.Name())
+
+	dtxBgpv4Peer := dtxBgpv4.
+		Peers().
+		Add().
+		SetAsNumber(tc["txAs"].(uint32)).
+		SetAsType(gosnappi.BgpV4PeerAsType.EBGP).
+		SetPeerAddress(tc["txGateway"].(string)).
+		SetName("dtxBgpv4Peer")
+
+	v4Scale.AddToV4Peer(dtxBgpv4Peer)
+	v6Scale.AddToV4Peer(dtxBgpv4Peer)
+
+	drxEth := drx.Ethernets().
+		Add().
+		SetName("drxEth").
+		SetMac(tc["rxMac"].(string)).
+		SetMtu(1500)
+
+	drxEth.Connection().SetPortName(prx.Name())
+
+	drxIp := drxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("drxIp").
+		SetAddress(tc["rxIp"].(string)).
+		SetGateway(tc["rxGateway"].(string)).
+		SetPrefix(tc["rxPrefix"].(uint32))
+
+	drxBgp := drx.Bgp().
+		SetRouterId(tc["rxIp"].(string))
+
+	drxBgpv4 := drxBgp.
+		Ipv4Interfaces().Add().
+		SetIpv4Name(drxIp.Name())
+
+	drxBgpv4Peer := drxBgpv4.
+		Peers().
+		Add().
+		SetAsNumber(tc["rxAs"].(uint32)).
+		SetAsType(gosnappi.BgpV4PeerAsType.EBGP).
+		SetPeerAddress(tc["rxGateway"].(string)).
+		SetName("drxBgpv4Peer")
+
+	drxBgpv4Peer.LearnedInformationFilter().SetUnicastIpv4Prefix(true).SetUnicastIpv6Prefix(true)
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func ebgpRouteScaleBgpMetricsOk(api *otg.OtgApi, v4Scale *otg.BgpRouteScale, v6Scale *otg.BgpRouteScale) bool {
+	routeCount := v4Scale.RouteCount() + v6Scale.RouteCount()
+	for _, m := range api.GetBgpv4Metrics() {
+		if m.SessionState() == gosnappi.Bgpv4MetricSessionState.DOWN {
+			return false
+		}
+		if m.Name() == "dtxBgpv4Peer" && m.RoutesAdvertised() != routeCount {
+			return false
+		}
+		if m.Name() == "drxBgpv4Peer" && m.RoutesReceived() != routeCount {
+			return false
+		}
+	}
+	return true
+}
+
+func ebgpRouteScaleBgpPrefixesOk(api *otg.OtgApi, v4Scale *otg.BgpRouteScale, v6Scale *otg.BgpRouteScale) bool {
+	t := api.Testing()
+	prefixes := api.GetBgpPrefixes()
+
+	return v4Scale.HasPrefixes(t, prefixes, "drxBgpv4Peer") &&
+		v6Scale.HasPrefixes(t, prefixes, "drxBgpv4Peer")
+}
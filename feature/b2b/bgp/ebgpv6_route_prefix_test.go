@@ -0,0 +1,407 @@
+//go:build all || cpdp
+
+package bgp
+
+import (
+	"testing"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+/* Demonstrates advertisement , storage and retrieval of IPv4 and IPv6
+   unicast routes over eBGP sessions established between IPv6 peers.
+   IPv4 routes are advertised with an IPv6 next hop, which requires
+   extended next hop encoding (RFC 8950) to be negotiated by both peers. */
+
+func TestEbgpv6RoutePrefix(t *testing.T) {
+	testConst := map[string]interface{}{
+		"pktRate":       uint64(50),
+		"pktCount":      uint32(100),
+		"pktSize":       uint32(128),
+		"txMac":         "00:00:01:01:01:01",
+		"txIpv6":        "1100::1",
+		"txv6Gateway":   "1100::2",
+		"txv6Prefix":    uint32(64),
+		"txRouterId":    "1.1.1.1",
+		"txAs":          uint32(1111),
+		"rxMac":         "00:00:01:01:01:02",
+		"rxIpv6":        "1100::2",
+		"rxv6Gateway":   "1100::1",
+		"rxv6Prefix":    uint32(64),
+		"rxRouterId":    "1.1.1.2",
+		"rxAs":          uint32(1112),
+		"txRouteCount":  uint32(1),
+		"rxRouteCount":  uint32(1),
+		"txExtNextHop":  "1100::3",
+		"txNextHopV6":   "1100::4",
+		"rxExtNextHop":  "1100::5",
+		"rxNextHopV6":   "1100::6",
+		"txAdvRouteV4":  "10.10.10.1",
+		"rxAdvRouteV4":  "20.20.20.1",
+		"txAdvRouteV6":  "::10:10:10:1",
+		"rxAdvRouteV6":  "::20:20:20:1",
+		"txAdvRouteMed": uint32(50),
+		"rxAdvRouteMed": uint32(60),
+	}
+
+	api := otg.NewOtgApi(t)
+	c := ebgpv6RoutePrefixConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	/* Check if BGPv6 sessions are up and expected routes are Txed and Rxed */
+	api.WaitFor(
+		func() bool { return ebgpv6RoutePrefixBgpMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv6Metrics"},
+	)
+
+	/* Check if each BGPv6 session recieved IPv4 routes with extended next hop */
+	api.WaitFor(
+		func() bool { return ebgpv6RoutePrefixBgpPrefixesOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForBgpRoutePrefixes"},
+	)
+
+	/* Check if each BGPv6 session recieved IPv6 routes with expected attributes */
+	api.WaitFor(
+		func() bool { return ebgpv6RoutePrefixBgpIpv6PrefixesOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForBgpIpv6RoutePrefixes"},
+	)
+
+	api.StartTransmit()
+
+	/* Check if traffic Rx and Tx stats are as expected (fixed pkt count) */
+	api.WaitFor(
+		func() bool { return ebgpv6RoutePrefixFlowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+}
+
+func ebgpv6RoutePrefixConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	dtx := c.Devices().Add().SetName("dtx")
+	drx := c.Devices().Add().SetName("drx")
+
+	dtxEth := dtx.Ethernets().
+		Add().
+		SetName("dtxEth").
+		SetMac(tc["txMac"].(string)).
+		SetMtu(1500)
+
+	dtxEth.Connection().SetPortName(ptx.Name())
+
+	dtxIpv6 := dtxEth.
+		Ipv6Addresses().
+		Add().
+		SetName("dtxIpv6").
+		SetAddress(tc["txIpv6"].(string)).
+		SetGateway(tc["txv6Gateway"].(string)).
+		SetPrefix(tc["txv6Prefix"].(uint32))
+
+	dtxBgp := dtx.Bgp().
+		SetRouterId(tc["txRouterId"].(string))
+
+	dtxBgpv6 := dtxBgp.
+		Ipv6Interfaces().Add().
+		SetIpv6Name(dtxIpv6.Name())
+
+	dtxBgpv6Peer := dtxBgpv6.
+		Peers().
+		Add().
+		SetAsNumber(tc["txAs"].(uint32)).
+		SetAsType(gosnappi.BgpV6PeerAsType.EBGP).
+		SetPeerAddress(tc["txv6Gateway"].(string)).
+		SetName("dtxBgpv6Peer")
+
+	dtxBgpv6Peer.Capability().
+		SetIpv4Unicast(true).
+		SetIpv6Unicast(true).
+		SetExtendedNextHopEncoding(true)
+
+	dtxBgpv6Peer.LearnedInformationFilter().SetUnicastIpv4Prefix(true).SetUnicastIpv6Prefix(true)
+
+	dtxBgpv6PeerRrV4 := dtxBgpv6Peer.
+		V4Routes().
+		Add().
+		SetNextHopIpv6Address(tc["txExtNextHop"].(string)).
+		SetName("dtxBgpv6PeerRrV4").
+		SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+
+	dtxBgpv6PeerRrV4.Addresses().Add().
+		SetAddress(tc["txAdvRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(tc["txRouteCount"].(uint32)).
+		SetStep(1)
+
+	dtxBgpv6PeerRrV4.Advanced().
+		SetMultiExitDiscriminator(tc["txAdvRouteMed"].(uint32)).
+		SetOrigin(gosnappi.BgpRouteAdvancedOrigin.EGP)
+
+	dtxBgpv6PeerRrV4.AsPath().
+		SetAsSetMode(gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SET).
+		Segments().Add().
+		SetAsNumbers([]uint32{1112, 1113}).
+		SetType(gosnappi.BgpAsPathSegmentType.AS_SEQ)
+
+	dtxBgpv6PeerRrV6 := dtxBgpv6Peer.
+		V6Routes().
+		Add().
+		SetNextHopIpv6Address(tc["txNextHopV6"].(string)).
+		SetName("dtxBgpv6PeerRrV6").
+		SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.MANUAL)
+
+	dtxBgpv6PeerRrV6.Addresses().Add().
+		SetAddress(tc["txAdvRouteV6"].(string)).
+		SetPrefix(128).
+		SetCount(tc["txRouteCount"].(uint32)).
+		SetStep(1)
+
+	dtxBgpv6PeerRrV6.Advanced().
+		SetMultiExitDiscriminator(tc["txAdvRouteMed"].(uint32)).
+		SetOrigin(gosnappi.BgpRouteAdvancedOrigin.EGP)
+
+	dtxBgpv6PeerRrV6.AsPath().
+		SetAsSetMode(gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SET).
+		Segments().Add().
+		SetAsNumbers([]uint32{1112, 1113}).
+		SetType(gosnappi.BgpAsPathSegmentType.AS_SEQ)
+
+	drxEth := drx.Ethernets().
+		Add().
+		SetName("drxEth").
+		SetMac(tc["rxMac"].(string)).
+		SetMtu(1500)
+
+	drxEth.Connection().SetPortName(prx.Name())
+
+	drxIpv6 := drxEth.
+		Ipv6Addresses().
+		Add().
+		SetName("drxIpv6").
+		SetAddress(tc["rxIpv6"].(string)).
+		SetGateway(tc["rxv6Gateway"].(string)).
+		SetPrefix(tc["rxv6Prefix"].(uint32))
+
+	drxBgp := drx.Bgp().
+		SetRouterId(tc["rxRouterId"].(string))
+
+	drxBgpv6 := drxBgp.
+		Ipv6Interfaces().Add().
+		SetIpv6Name(drxIpv6.Name())
+
+	drxBgpv6Peer := drxBgpv6.
+		Peers().
+		Add().
+		SetAsNumber(tc["rxAs"].(uint32)).
+		SetAsType(gosnappi.BgpV6PeerAsType.EBGP).
+		SetPeerAddress(tc["rxv6Gateway"].(string)).
+		SetName("drxBgpv6Peer")
+
+	drxBgpv6Peer.Capability().
+		SetIpv4Unicast(true).
+		SetIpv6Unicast(true).
+		SetExtendedNextHopEncoding(true)
+
+	drxBgpv6Peer.LearnedInformationFilter().SetUnicastIpv4Prefix(true).SetUnicastIpv6Prefix(true)
+
+	drxBgpv6PeerRrV4 := drxBgpv6Peer.
+		V4Routes().
+		Add().
+		SetNextHopIpv6Address(tc["rxExtNextHop"].(string)).
+		SetName("drxBgpv6PeerRrV4").
+		SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+
+	drxBgpv6PeerRrV4.Addresses().Add().
+		SetAddress(tc["rxAdvRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(tc["rxRouteCount"].(uint32)).
+		SetStep(1)
+
+	drxBgpv6PeerRrV4.Advanced().
+		SetMultiExitDiscriminator(tc["rxAdvRouteMed"].(uint32)).
+		SetOrigin(gosnappi.BgpRouteAdvancedOrigin.EGP)
+
+	drxBgpv6PeerRrV4.AsPath().
+		SetAsSetMode(gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SET).
+		Segments().Add().
+		SetAsNumbers([]uint32{4444}).
+		SetType(gosnappi.BgpAsPathSegmentType.AS_SEQ)
+
+	drxBgpv6PeerRrV6 := drxBgpv6Peer.
+		V6Routes().
+		Add().
+		SetNextHopIpv6Address(tc["rxNextHopV6"].(string)).
+		SetName("drxBgpv6PeerRrV6").
+		SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.MANUAL)
+
+	drxBgpv6PeerRrV6.Addresses().Add().
+		SetAddress(tc["rxAdvRouteV6"].(string)).
+		SetPrefix(128).
+		SetCount(tc["rxRouteCount"].(uint32)).
+		SetStep(1)
+
+	drxBgpv6PeerRrV6.Advanced().
+		SetMultiExitDiscriminator(tc["rxAdvRouteMed"].(uint32)).
+		SetOrigin(gosnappi.BgpRouteAdvancedOrigin.EGP)
+
+	drxBgpv6PeerRrV6.AsPath().
+		SetAsSetMode(gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SET).
+		Segments().Add().
+		SetAsNumbers([]uint32{4444}).
+		SetType(gosnappi.BgpAsPathSegmentType.AS_SEQ)
+
+	for i := 1; i <= 4; i++ {
+		flow := c.Flows().Add()
+		flow.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+		flow.Rate().SetPps(tc["pktRate"].(uint64))
+		flow.Size().SetFixed(tc["pktSize"].(uint32))
+		flow.Metrics().SetEnable(true)
+	}
+
+	ftxV4 := c.Flows().Items()[0]
+	ftxV4.SetName("ftxV4")
+	ftxV4.TxRx().Device().
+		SetTxNames([]string{dtxBgpv6PeerRrV4.Name()}).
+		SetRxNames([]string{drxBgpv6PeerRrV4.Name()})
+
+	ftxV4Eth := ftxV4.Packet().Add().Ethernet()
+	ftxV4Eth.Src().SetValue(dtxEth.Mac())
+
+	ftxV4Ip := ftxV4.Packet().Add().Ipv4()
+	ftxV4Ip.Src().SetValue(tc["txAdvRouteV4"].(string))
+	ftxV4Ip.Dst().SetValue(tc["rxAdvRouteV4"].(string))
+
+	ftxV4Tcp := ftxV4.Packet().Add().Tcp()
+	ftxV4Tcp.SrcPort().SetValue(5000)
+	ftxV4Tcp.DstPort().SetValue(6000)
+
+	ftxV6 := c.Flows().Items()[1]
+	ftxV6.SetName("ftxV6")
+	ftxV6.TxRx().Device().
+		SetTxNames([]string{dtxBgpv6PeerRrV6.Name()}).
+		SetRxNames([]string{drxBgpv6PeerRrV6.Name()})
+
+	ftxV6Eth := ftxV6.Packet().Add().Ethernet()
+	ftxV6Eth.Src().SetValue(dtxEth.Mac())
+
+	ftxV6Ip := ftxV6.Packet().Add().Ipv6()
+	ftxV6Ip.Src().SetValue(tc["txAdvRouteV6"].(string))
+	ftxV6Ip.Dst().SetValue(tc["rxAdvRouteV6"].(string))
+
+	ftxV6Tcp := ftxV6.Packet().Add().Tcp()
+	ftxV6Tcp.SrcPort().SetValue(5000)
+	ftxV6Tcp.DstPort().SetValue(6000)
+
+	frxV4 := c.Flows().Items()[2]
+	frxV4.SetName("frxV4")
+	frxV4.TxRx().Device().
+		SetTxNames([]string{drxBgpv6PeerRrV4.Name()}).
+		SetRxNames([]string{dtxBgpv6PeerRrV4.Name()})
+
+	frxV4Eth := frxV4.Packet().Add().Ethernet()
+	frxV4Eth.Src().SetValue(drxEth.Mac())
+
+	frxV4Ip := frxV4.Packet().Add().Ipv4()
+	frxV4Ip.Src().SetValue(tc["rxAdvRouteV4"].(string))
+	frxV4Ip.Dst().SetValue(tc["txAdvRouteV4"].(string))
+
+	frxV4Tcp := frxV4.Packet().Add().Tcp()
+	frxV4Tcp.SrcPort().SetValue(6000)
+	frxV4Tcp.DstPort().SetValue(5000)
+
+	frxV6 := c.Flows().Items()[3]
+	frxV6.SetName("frxV6")
+	frxV6.TxRx().Device().
+		SetTxNames([]string{drxBgpv6PeerRrV6.Name()}).
+		SetRxNames([]string{dtxBgpv6PeerRrV6.Name()})
+
+	frxV6Eth := frxV6.Packet().Add().Ethernet()
+	frxV6Eth.Src().SetValue(drxEth.Mac())
+
+	frxV6Ip := frxV6.Packet().Add().Ipv6()
+	frxV6Ip.Src().SetValue(tc["rxAdvRouteV6"].(string))
+	frxV6Ip.Dst().SetValue(tc["txAdvRouteV6"].(string))
+
+	frxV6Tcp := frxV6.Packet().Add().Tcp()
+	frxV6Tcp.SrcPort().SetValue(6000)
+	frxV6Tcp.DstPort().SetValue(5000)
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func ebgpv6RoutePrefixBgpMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	for _, m := range api.GetBgpv6Metrics() {
+		if m.SessionState() == gosnappi.Bgpv6MetricSessionState.DOWN ||
+			m.RoutesAdvertised() != 2*uint64(tc["txRouteCount"].(uint32)) ||
+			m.RoutesReceived() != 2*uint64(tc["rxRouteCount"].(uint32)) {
+			return false
+		}
+	}
+	return true
+}
+
+func ebgpv6RoutePrefixBgpPrefixesOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	prefixCount := 0
+	for _, m := range api.GetBgpPrefixes() {
+		for _, p := range m.Ipv4UnicastPrefixes().Items() {
+			for _, key := range []string{"tx", "rx"} {
+				// IPv4 routes learnt over IPv6 sessions shall only carry an IPv6 next hop
+				if p.Ipv4Address() == tc[key+"AdvRouteV4"].(string) &&
+					p.Ipv6NextHop() == tc[key+"ExtNextHop"].(string) &&
+					!p.HasIpv4NextHop() &&
+					p.MultiExitDiscriminator() == tc[key+"AdvRouteMed"].(uint32) {
+					prefixCount += 1
+				}
+			}
+		}
+	}
+	return prefixCount == 2
+}
+
+func ebgpv6RoutePrefixBgpIpv6PrefixesOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	prefixCount := 0
+	for _, m := range api.GetBgpPrefixes() {
+		for _, p := range m.Ipv6UnicastPrefixes().Items() {
+			for _, key := range []string{"tx", "rx"} {
+				if p.Ipv6Address() == tc[key+"AdvRouteV6"].(string) &&
+					p.Ipv6NextHop() == tc[key+"NextHopV6"].(string) &&
+					p.Origin() == gosnappi.BgpPrefixIpv6UnicastStateOrigin.EGP &&
+					p.MultiExitDiscriminator() == tc[key+"AdvRouteMed"].(uint32) {
+					prefixCount += 1
+				}
+			}
+		}
+	}
+	return prefixCount == 2
+}
+
+func ebgpv6RoutePrefixFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	pktCount := uint64(tc["pktCount"].(uint32))
+
+	for _, m := range api.GetFlowMetrics() {
+		if m.Transmit() != gosnappi.FlowMetricTransmit.STOPPED ||
+			m.FramesTx() != pktCount ||
+			m.FramesRx() != pktCount {
+			return false
+		}
+
+	}
+
+	return true
+}
@@ -0,0 +1,280 @@
+//go:build all || cpdp
+
+package bgp
+
+import (
+	"testing"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+/* Demonstrates advertisement , storage and retrieval of IPv4 and IPv6
+   unicast routes with MED and Local Preference over iBGP sessions
+   established between IPv6 peers.
+   IPv4 routes are advertised with an IPv6 next hop (extended next hop
+   encoding) while IPv6 routes are advertised with a manual IPv6 next hop.
+   Flows are excluded in this example to simplify the test.
+   For configuration and access of flow stats refer to ebgpv6_route_prefix_test.go */
+
+func TestIbgpv6RoutePrefix(t *testing.T) {
+	testConst := map[string]interface{}{
+		"txMac":        "00:00:01:01:01:01",
+		"txIpv6":       "1100::1",
+		"txv6Gateway":  "1100::2",
+		"txv6Prefix":   uint32(64),
+		"txRouterId":   "1.1.1.1",
+		"txAs":         uint32(1111),
+		"rxMac":        "00:00:01:01:01:02",
+		"rxIpv6":       "1100::2",
+		"rxv6Gateway":  "1100::1",
+		"rxv6Prefix":   uint32(64),
+		"rxRouterId":   "1.1.1.2",
+		"rxAs":         uint32(1111),
+		"txRouteCount": uint32(1),
+		"rxRouteCount": uint32(1),
+		"txExtNextHop": "1100::3",
+		"txNextHopV6":  "1100::4",
+		"rxExtNextHop": "1100::5",
+		"rxNextHopV6":  "1100::6",
+		"txAdvRouteV4": "10.10.10.1",
+		"rxAdvRouteV4": "20.20.20.1",
+		"txAdvRouteV6": "::10:10:10:1",
+		"rxAdvRouteV6": "::20:20:20:1",
+		"advRouteMed":  uint32(50),
+		"advLocalPref": uint32(200),
+	}
+
+	api := otg.NewOtgApi(t)
+	c := ibgpv6RoutePrefixConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	/* Check if BGPv6 sessions are up and expected routes are Txed and Rxed */
+	api.WaitFor(
+		func() bool { return ibgpv6RoutePrefixBgpMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForBgpv6Metrics"},
+	)
+
+	/* Check if each BGPv6 session recieved routes with expected attributes */
+	api.WaitFor(
+		func() bool { return ibgpv6RoutePrefixBgpPrefixesOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForBgpRoutePrefixes"},
+	)
+}
+
+func ibgpv6RoutePrefixConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	dtx := c.Devices().Add().SetName("dtx")
+	drx := c.Devices().Add().SetName("drx")
+
+	dtxEth := dtx.Ethernets().
+		Add().
+		SetName("dtxEth").
+		SetMac(tc["txMac"].(string)).
+		SetMtu(1500)
+
+	dtxEth.Connection().SetPortName(ptx.Name())
+
+	dtxIpv6 := dtxEth.
+		Ipv6Addresses().
+		Add().
+		SetName("dtxIpv6").
+		SetAddress(tc["txIpv6"].(string)).
+		SetGateway(tc["txv6Gateway"].(string)).
+		SetPrefix(tc["txv6Prefix"].(uint32))
+
+	dtxBgp := dtx.Bgp().
+		SetRouterId(tc["txRouterId"].(string))
+
+	dtxBgpv6 := dtxBgp.
+		Ipv6Interfaces().Add().
+		SetIpv6Name(dtxIpv6.Name())
+
+	dtxBgpv6Peer := dtxBgpv6.
+		Peers().
+		Add().
+		SetAsNumber(tc["txAs"].(uint32)).
+		SetAsType(gosnappi.BgpV6PeerAsType.IBGP).
+		SetPeerAddress(tc["txv6Gateway"].(string)).
+		SetName("dtxBgpv6Peer")
+
+	dtxBgpv6Peer.Capability().
+		SetIpv4Unicast(true).
+		SetIpv6Unicast(true).
+		SetExtendedNextHopEncoding(true)
+
+	dtxBgpv6Peer.LearnedInformationFilter().SetUnicastIpv4Prefix(true).SetUnicastIpv6Prefix(true)
+
+	dtxBgpv6PeerRrV4 := dtxBgpv6Peer.
+		V4Routes().
+		Add().
+		SetName("dtxBgpv6PeerRrV4").
+		SetNextHopIpv6Address(tc["txExtNextHop"].(string)).
+		SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+
+	dtxBgpv6PeerRrV4.Addresses().Add().
+		SetAddress(tc["txAdvRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(tc["txRouteCount"].(uint32)).
+		SetStep(1)
+
+	dtxBgpv6PeerRrV4.Advanced().
+		SetMultiExitDiscriminator(tc["advRouteMed"].(uint32)).
+		SetLocalPreference(tc["advLocalPref"].(uint32)).
+		SetOrigin(gosnappi.BgpRouteAdvancedOrigin.EGP)
+
+	dtxBgpv6PeerRrV6 := dtxBgpv6Peer.
+		V6Routes().
+		Add().
+		SetName("dtxBgpv6PeerRrV6").
+		SetNextHopIpv6Address(tc["txNextHopV6"].(string)).
+		SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.MANUAL)
+
+	dtxBgpv6PeerRrV6.Addresses().Add().
+		SetAddress(tc["txAdvRouteV6"].(string)).
+		SetPrefix(128).
+		SetCount(tc["txRouteCount"].(uint32)).
+		SetStep(1)
+
+	dtxBgpv6PeerRrV6.Advanced().
+		SetMultiExitDiscriminator(tc["advRouteMed"].(uint32)).
+		SetLocalPreference(tc["advLocalPref"].(uint32)).
+		SetOrigin(gosnappi.BgpRouteAdvancedOrigin.EGP)
+
+	drxEth := drx.Ethernets().
+		Add().
+		SetName("drxEth").
+		SetMac(tc["rxMac"].(string)).
+		SetMtu(1500)
+
+	drxEth.Connection().SetPortName(prx.Name())
+
+	drxIpv6 := drxEth.
+		Ipv6Addresses().
+		Add().
+		SetName("drxIpv6").
+		SetAddress(tc["rxIpv6"].(string)).
+		SetGateway(tc["rxv6Gateway"].(string)).
+		SetPrefix(tc["rxv6Prefix"].(uint32))
+
+	drxBgp := drx.Bgp().
+		SetRouterId(tc["rxRouterId"].(string))
+
+	drxBgpv6 := drxBgp.
+		Ipv6Interfaces().Add().
+		SetIpv6Name(drxIpv6.Name())
+
+	drxBgpv6Peer := drxBgpv6.
+		Peers().
+		Add().
+		SetAsNumber(tc["rxAs"].(uint32)).
+		SetAsType(gosnappi.BgpV6PeerAsType.IBGP).
+		SetPeerAddress(tc["rxv6Gateway"].(string)).
+		SetName("drxBgpv6Peer")
+
+	drxBgpv6Peer.Capability().
+		SetIpv4Unicast(true).
+		SetIpv6Unicast(true).
+		SetExtendedNextHopEncoding(true)
+
+	drxBgpv6Peer.LearnedInformationFilter().SetUnicastIpv4Prefix(true).SetUnicastIpv6Prefix(true)
+
+	drxBgpv6PeerRrV4 := drxBgpv6Peer.
+		V4Routes().
+		Add().
+		SetName("drxBgpv6PeerRrV4").
+		SetNextHopIpv6Address(tc["rxExtNextHop"].(string)).
+		SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+
+	drxBgpv6PeerRrV4.Addresses().Add().
+		SetAddress(tc["rxAdvRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(tc["rxRouteCount"].(uint32)).
+		SetStep(1)
+
+	drxBgpv6PeerRrV4.Advanced().
+		SetMultiExitDiscriminator(tc["advRouteMed"].(uint32)).
+		SetLocalPreference(tc["advLocalPref"].(uint32)).
+		SetOrigin(gosnappi.BgpRouteAdvancedOrigin.EGP)
+
+	drxBgpv6PeerRrV6 := drxBgpv6Peer.
+		V6Routes().
+		Add().
+		SetName("drxBgpv6PeerRrV6").
+		SetNextHopIpv6Address(tc["rxNextHopV6"].(string)).
+		SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.MANUAL)
+
+	drxBgpv6PeerRrV6.Addresses().Add().
+		SetAddress(tc["rxAdvRouteV6"].(string)).
+		SetPrefix(128).
+		SetCount(tc["rxRouteCount"].(uint32)).
+		SetStep(1)
+
+	drxBgpv6PeerRrV6.Advanced().
+		SetMultiExitDiscriminator(tc["advRouteMed"].(uint32)).
+		SetLocalPreference(tc["advLocalPref"].(uint32)).
+		SetOrigin(gosnappi.BgpRouteAdvancedOrigin.EGP)
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func ibgpv6RoutePrefixBgpMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	for _, m := range api.GetBgpv6Metrics() {
+		if m.SessionState() == gosnappi.Bgpv6MetricSessionState.DOWN ||
+			m.RoutesAdvertised() != 2*uint64(tc["txRouteCount"].(uint32)) ||
+			m.RoutesReceived() != 2*uint64(tc["rxRouteCount"].(uint32)) {
+			return false
+		}
+	}
+	return true
+}
+
+func ibgpv6RoutePrefixBgpPrefixesOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	prefixCount := 0
+	for _, m := range api.GetBgpPrefixes() {
+		for _, p := range m.Ipv4UnicastPrefixes().Items() {
+			for _, key := range []string{"tx", "rx"} {
+				if p.Ipv4Address() == tc[key+"AdvRouteV4"].(string) && p.Ipv6NextHop() == tc[key+"ExtNextHop"].(string) {
+					prefixCount += 1
+				}
+			}
+			if p.LocalPreference() != tc["advLocalPref"].(uint32) {
+				api.Testing().Logf("Unexpected LocalPref %v \n", p.LocalPreference())
+				return false
+			}
+			if p.MultiExitDiscriminator() != tc["advRouteMed"].(uint32) {
+				api.Testing().Logf("Unexpected MED %v \n", p.MultiExitDiscriminator())
+				return false
+			}
+		}
+		for _, p := range m.Ipv6UnicastPrefixes().Items() {
+			for _, key := range []string{"tx", "rx"} {
+				if p.Ipv6Address() == tc[key+"AdvRouteV6"].(string) && p.Ipv6NextHop() == tc[key+"NextHopV6"].(string) {
+					prefixCount += 1
+				}
+			}
+			if p.LocalPreference() != tc["advLocalPref"].(uint32) {
+				api.Testing().Logf("Unexpected LocalPref %v \n", p.LocalPreference())
+				return false
+			}
+		}
+	}
+	return prefixCount == 4
+}
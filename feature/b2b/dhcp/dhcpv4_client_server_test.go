@@ -0,0 +1,285 @@
+//go:build all || cpdp
+
+package dhcp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestDhcpv4ClientServer(t *testing.T) {
+	testConst := map[string]interface{}{
+		"pktRate":       uint64(50),
+		"pktCount":      uint32(100),
+		"pktSize":       uint32(128),
+		"clientCount":   2,
+		"clientMac":     "00:00:01:01:01:01",
+		"serverMac":     "00:00:01:01:01:02",
+		"serverIp":      "10.1.1.1",
+		"serverGateway": "10.1.1.2",
+		"serverPrefix":  uint32(24),
+		"poolStart":     "10.1.1.100",
+		"poolPrefix":    uint32(24),
+		"leaseTime":     uint32(3600),
+	}
+
+	api := otg.NewOtgApi(t)
+	c := dhcpv4ClientServerConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return dhcpv4ClientServerMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForDhcpv4Metrics"},
+	)
+
+	leased := map[string]string{}
+	api.WaitFor(
+		func() bool {
+			leased = dhcpv4ClientServerLeased(api, testConst)
+			return len(leased) == testConst["clientCount"].(int)
+		},
+		&otg.WaitForOpts{FnName: "WaitForDhcpv4Interfaces"},
+	)
+
+	dhcpv4ClientServerLeasesOk(api, testConst, leased)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return flowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+}
+
+func dhcpv4ClientServerConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	ds := c.Devices().Add().SetName("ds")
+
+	dsEth := ds.Ethernets().
+		Add().
+		SetName("dsEth").
+		SetMac(tc["serverMac"].(string)).
+		SetMtu(1500)
+
+	dsEth.Connection().SetPortName(prx.Name())
+
+	dsIp := dsEth.
+		Ipv4Addresses().
+		Add().
+		SetName("dsIp").
+		SetAddress(tc["serverIp"].(string)).
+		SetGateway(tc["serverGateway"].(string)).
+		SetPrefix(tc["serverPrefix"].(uint32))
+
+	dsPool := ds.DhcpServer().
+		Ipv4Interfaces().
+		Add().
+		SetName("dsDhcpv4").
+		SetIpv4Name(dsIp.Name()).
+		AddressPools().
+		Add().
+		SetName("dsPool").
+		SetLeaseTime(tc["leaseTime"].(uint32)).
+		SetStartAddress(tc["poolStart"].(string)).
+		SetPrefixLength(tc["poolPrefix"].(uint32)).
+		SetCount(uint32(tc["clientCount"].(int))).
+		SetStep(1)
+
+	/* clients are expected to use server as gateway */
+	dsPool.Options().SetRouterAddress(dsIp.Address())
+
+	clientMac, _ := net.ParseMAC(tc["clientMac"].(string))
+	for i := 1; i <= tc["clientCount"].(int); i++ {
+		dc := c.Devices().Add().SetName(fmt.Sprintf("dc%d", i))
+
+		dcEth := dc.Ethernets().
+			Add().
+			SetName(fmt.Sprintf("dc%dEth", i)).
+			SetMac(clientMac.String()).
+			SetMtu(1500)
+
+		dcEth.Connection().SetPortName(ptx.Name())
+
+		dcDhcp := dcEth.
+			Dhcpv4Interfaces().
+			Add().
+			SetName(fmt.Sprintf("dc%dDhcpv4", i))
+
+		dcDhcp.FirstServer()
+		dcDhcp.SetBroadcast(true)
+		dcDhcp.ParametersRequestList().
+			SetSubnetMask(true).
+			SetRouter(true).
+			SetRenewalTimer(true).
+			SetRebindingTimer(true)
+
+		/* flows to and from leased address */
+		for _, f := range []struct {
+			name     string
+			txName   string
+			rxName   string
+			ethSrc   string
+			ethDst   string
+			fromDhcp bool
+		}{
+			{fmt.Sprintf("fdc%d", i), dcDhcp.Name(), dsIp.Name(), dcEth.Mac(), dsEth.Mac(), true},
+			{fmt.Sprintf("fds%d", i), dsIp.Name(), dcDhcp.Name(), dsEth.Mac(), dcEth.Mac(), false},
+		} {
+			flow := c.Flows().Add()
+			flow.SetName(f.name)
+			flow.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+			flow.Rate().SetPps(tc["pktRate"].(uint64))
+			flow.Size().SetFixed(tc["pktSize"].(uint32))
+			flow.Metrics().SetEnable(true)
+
+			flow.TxRx().Device().
+				SetTxNames([]string{f.txName}).
+				SetRxNames([]string{f.rxName})
+
+			/* destination MAC is set explicitly since server gateway is not one of the clients */
+			fEth := flow.Packet().Add().Ethernet()
+			fEth.Src().SetValue(f.ethSrc)
+			fEth.Dst().SetValue(f.ethDst)
+
+			fIp := flow.Packet().Add().Ipv4()
+			if f.fromDhcp {
+				fIp.Src().Auto().Dhcp()
+				fIp.Dst().SetValue(dsIp.Address())
+			} else {
+				fIp.Src().SetValue(dsIp.Address())
+				fIp.Dst().Auto().Dhcp()
+			}
+		}
+
+		clientMac[5] += 1
+	}
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func dhcpv4ClientServerMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	clientCount := tc["clientCount"].(int)
+
+	acked := 0
+	for _, m := range api.GetDhcpv4ClientMetrics() {
+		if m.NacksReceived() != 0 || m.DeclinesSent() != 0 {
+			api.Testing().Fatalf("ERROR: DHCPv4 client %s received %d NAKs and sent %d declines\n", m.Name(), m.NacksReceived(), m.DeclinesSent())
+		}
+		if m.DiscoversSent() >= 1 && m.OffersReceived() >= 1 &&
+			m.RequestsSent() >= 1 && m.AcksReceived() >= 1 {
+			acked += 1
+		}
+	}
+
+	for _, m := range api.GetDhcpv4ServerMetrics() {
+		if m.DiscoversReceived() < uint64(clientCount) || m.OffersSent() < uint64(clientCount) ||
+			m.RequestsReceived() < uint64(clientCount) || m.AcksSent() < uint64(clientCount) ||
+			m.NacksSent() != 0 {
+			return false
+		}
+	}
+
+	return acked == clientCount
+}
+
+// dhcpv4ClientServerInPool returns true if addr is one of count addresses
+// starting at start
+func dhcpv4ClientServerInPool(addr string, start string, count int) bool {
+	a := net.ParseIP(addr).To4()
+	s := net.ParseIP(start).To4()
+	if a == nil || s == nil {
+		return false
+	}
+
+	offset := binary.BigEndian.Uint32(a) - binary.BigEndian.Uint32(s)
+	return offset < uint32(count)
+}
+
+// dhcpv4ClientServerLeased returns client names keyed by leased address after
+// validating lease parameters learnt by each client
+func dhcpv4ClientServerLeased(api *otg.OtgApi, tc map[string]interface{}) map[string]string {
+	t := api.Testing()
+	clientCount := tc["clientCount"].(int)
+
+	leased := map[string]string{}
+	for _, i := range api.GetDhcpv4Interfaces() {
+		if !i.HasIpv4Address() {
+			continue
+		}
+		if !dhcpv4ClientServerInPool(i.Ipv4Address(), tc["poolStart"].(string), clientCount) {
+			t.Fatalf("ERROR: DHCPv4 client %s leased %s outside of pool\n", i.DhcpClientName(), i.Ipv4Address())
+		}
+		if i.PrefixLength() != tc["poolPrefix"].(uint32) {
+			t.Fatalf("ERROR: DHCPv4 client %s prefix length %d != %d\n", i.DhcpClientName(), i.PrefixLength(), tc["poolPrefix"].(uint32))
+		}
+		if i.GatewayAddress() != tc["serverIp"].(string) {
+			t.Fatalf("ERROR: DHCPv4 client %s gateway %s != %s\n", i.DhcpClientName(), i.GatewayAddress(), tc["serverIp"].(string))
+		}
+		/* client may report remaining lease time */
+		if i.LeaseTime() == 0 || i.LeaseTime() > tc["leaseTime"].(uint32) {
+			t.Fatalf("ERROR: DHCPv4 client %s lease time %d not in (0, %d]\n", i.DhcpClientName(), i.LeaseTime(), tc["leaseTime"].(uint32))
+		}
+		if c, ok := leased[i.Ipv4Address()]; ok {
+			t.Fatalf("ERROR: DHCPv4 clients %s and %s leased same address %s\n", c, i.DhcpClientName(), i.Ipv4Address())
+		}
+		leased[i.Ipv4Address()] = i.DhcpClientName()
+	}
+
+	return leased
+}
+
+func dhcpv4ClientServerLeasesOk(api *otg.OtgApi, tc map[string]interface{}, leased map[string]string) {
+	t := api.Testing()
+
+	count := 0
+	for _, s := range api.GetDhcpv4Leases() {
+		if s.DhcpServerName() != "dsDhcpv4" {
+			continue
+		}
+		for _, l := range s.Leases().Items() {
+			if _, ok := leased[l.Address()]; !ok {
+				t.Fatalf("ERROR: DHCPv4 server lease %s not held by any client\n", l.Address())
+			}
+			if l.HasValidTime() && l.ValidTime() > tc["leaseTime"].(uint32) {
+				t.Fatalf("ERROR: DHCPv4 server lease %s valid time %d > %d\n", l.Address(), l.ValidTime(), tc["leaseTime"].(uint32))
+			}
+			count += 1
+		}
+	}
+
+	if count != len(leased) {
+		t.Fatalf("ERROR: DHCPv4 server leases %d != client leases %d\n", count, len(leased))
+	}
+}
+
+func flowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	pktCount := uint64(tc["pktCount"].(uint32))
+
+	for _, m := range api.GetFlowMetrics() {
+		if m.Transmit() != gosnappi.FlowMetricTransmit.STOPPED ||
+			m.FramesTx() != pktCount ||
+			m.FramesRx() != pktCount {
+			return false
+		}
+	}
+
+	return true
+}
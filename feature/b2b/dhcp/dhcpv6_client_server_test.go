@@ -0,0 +1,288 @@
+//go:build all || cpdp
+
+package dhcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func dhcpv6ClientServerTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktRate":       uint64(50),
+		"pktCount":      uint32(100),
+		"pktSize":       uint32(128),
+		"clientCount":   2,
+		"clientMac":     "00:00:01:01:01:01",
+		"serverMac":     "00:00:01:01:01:02",
+		"serverIp":      "2000::1",
+		"serverGateway": "2000::2",
+		"serverPrefix":  uint32(64),
+		"poolStart":     "2000::100",
+		"poolPrefix":    uint32(64),
+		"leaseTime":     uint32(3600),
+		"rapidCommit":   false,
+	}
+}
+
+func TestDhcpv6ClientServer(t *testing.T) {
+	testConst := dhcpv6ClientServerTestConst()
+
+	dhcpv6ClientServerTest(t, testConst)
+}
+
+func TestDhcpv6ClientServerRapidCommit(t *testing.T) {
+	testConst := dhcpv6ClientServerTestConst()
+	/* address gets assigned with two message exchange (solicit / reply) */
+	testConst["rapidCommit"] = true
+
+	dhcpv6ClientServerTest(t, testConst)
+}
+
+func dhcpv6ClientServerTest(t *testing.T, testConst map[string]interface{}) {
+	api := otg.NewOtgApi(t)
+	c := dhcpv6ClientServerConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return dhcpv6ClientServerMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForDhcpv6Metrics"},
+	)
+
+	leased := map[string]string{}
+	api.WaitFor(
+		func() bool {
+			leased = dhcpv6ClientServerLeased(api, testConst)
+			return len(leased) == testConst["clientCount"].(int)
+		},
+		&otg.WaitForOpts{FnName: "WaitForDhcpv6Interfaces"},
+	)
+
+	dhcpv6ClientServerLeasesOk(api, testConst, leased)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return flowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+}
+
+func dhcpv6ClientServerConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	ds := c.Devices().Add().SetName("ds")
+
+	dsEth := ds.Ethernets().
+		Add().
+		SetName("dsEth").
+		SetMac(tc["serverMac"].(string)).
+		SetMtu(1500)
+
+	dsEth.Connection().SetPortName(prx.Name())
+
+	dsIp := dsEth.
+		Ipv6Addresses().
+		Add().
+		SetName("dsIp").
+		SetAddress(tc["serverIp"].(string)).
+		SetGateway(tc["serverGateway"].(string)).
+		SetPrefix(tc["serverPrefix"].(uint32))
+
+	ds.DhcpServer().
+		Ipv6Interfaces().
+		Add().
+		SetName("dsDhcpv6").
+		SetIpv6Name(dsIp.Name()).
+		SetRapidCommit(tc["rapidCommit"].(bool)).
+		Leases().
+		Add().
+		SetLeaseTime(tc["leaseTime"].(uint32)).
+		IaType().
+		Iana().
+		SetStartAddress(tc["poolStart"].(string)).
+		SetPrefixLen(tc["poolPrefix"].(uint32)).
+		SetSize(uint32(tc["clientCount"].(int))).
+		SetStep(1)
+
+	clientMac, _ := net.ParseMAC(tc["clientMac"].(string))
+	for i := 1; i <= tc["clientCount"].(int); i++ {
+		dc := c.Devices().Add().SetName(fmt.Sprintf("dc%d", i))
+
+		dcEth := dc.Ethernets().
+			Add().
+			SetName(fmt.Sprintf("dc%dEth", i)).
+			SetMac(clientMac.String()).
+			SetMtu(1500)
+
+		dcEth.Connection().SetPortName(ptx.Name())
+
+		dcDhcp := dcEth.
+			Dhcpv6Interfaces().
+			Add().
+			SetName(fmt.Sprintf("dc%dDhcpv6", i)).
+			SetRapidCommit(tc["rapidCommit"].(bool))
+
+		dcDhcp.IaType().Iana()
+		dcDhcp.DuidType().Llt()
+
+		/* flows to and from leased address */
+		for _, f := range []struct {
+			name     string
+			txName   string
+			rxName   string
+			ethSrc   string
+			ethDst   string
+			fromDhcp bool
+		}{
+			{fmt.Sprintf("fdc%d", i), dcDhcp.Name(), dsIp.Name(), dcEth.Mac(), dsEth.Mac(), true},
+			{fmt.Sprintf("fds%d", i), dsIp.Name(), dcDhcp.Name(), dsEth.Mac(), dcEth.Mac(), false},
+		} {
+			flow := c.Flows().Add()
+			flow.SetName(f.name)
+			flow.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+			flow.Rate().SetPps(tc["pktRate"].(uint64))
+			flow.Size().SetFixed(tc["pktSize"].(uint32))
+			flow.Metrics().SetEnable(true)
+
+			flow.TxRx().Device().
+				SetTxNames([]string{f.txName}).
+				SetRxNames([]string{f.rxName})
+
+			/* DHCPv6 does not provide a gateway, hence destination MAC is set explicitly */
+			fEth := flow.Packet().Add().Ethernet()
+			fEth.Src().SetValue(f.ethSrc)
+			fEth.Dst().SetValue(f.ethDst)
+
+			fIp := flow.Packet().Add().Ipv6()
+			if f.fromDhcp {
+				fIp.Src().Auto().Dhcp()
+				fIp.Dst().SetValue(dsIp.Address())
+			} else {
+				fIp.Src().SetValue(dsIp.Address())
+				fIp.Dst().Auto().Dhcp()
+			}
+		}
+
+		clientMac[5] += 1
+	}
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func dhcpv6ClientServerMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	clientCount := uint64(tc["clientCount"].(int))
+	rapidCommit := tc["rapidCommit"].(bool)
+
+	replied := uint64(0)
+	for _, m := range api.GetDhcpv6ClientMetrics() {
+		if m.NacksReceived() != 0 {
+			api.Testing().Fatalf("ERROR: DHCPv6 client %s received %d NAKs\n", m.Name(), m.NacksReceived())
+		}
+		if rapidCommit {
+			if m.RapidCommitSolicitsSent() >= 1 && m.RapidCommitRepliesReceived() >= 1 {
+				replied += 1
+			}
+		} else if m.SolicitsSent() >= 1 && m.AdvertisementsReceived() >= 1 &&
+			m.RequestsSent() >= 1 && m.RepliesReceived() >= 1 {
+			replied += 1
+		}
+	}
+
+	for _, m := range api.GetDhcpv6ServerMetrics() {
+		if m.SolicitsReceived() < clientCount || m.RepliesSent() < clientCount || m.NacksSent() != 0 {
+			return false
+		}
+		if !rapidCommit && (m.AdvertisementsSent() < clientCount || m.RequestsReceived() < clientCount) {
+			return false
+		}
+	}
+
+	return replied == clientCount
+}
+
+// dhcpv6ClientServerInPool returns true if addr is one of count addresses
+// starting at start
+func dhcpv6ClientServerInPool(addr string, start string, count int) bool {
+	a := net.ParseIP(addr).To16()
+	s := net.ParseIP(start).To16()
+	if a == nil || s == nil || !bytes.Equal(a[:8], s[:8]) {
+		return false
+	}
+
+	offset := binary.BigEndian.Uint64(a[8:]) - binary.BigEndian.Uint64(s[8:])
+	return offset < uint64(count)
+}
+
+// dhcpv6ClientServerLeased returns client names keyed by leased address after
+// validating lease parameters learnt by each client
+func dhcpv6ClientServerLeased(api *otg.OtgApi, tc map[string]interface{}) map[string]string {
+	t := api.Testing()
+	clientCount := tc["clientCount"].(int)
+
+	leased := map[string]string{}
+	for _, i := range api.GetDhcpv6Interfaces() {
+		for _, a := range i.IaAddresses().Items() {
+			if !a.HasAddress() {
+				continue
+			}
+			addr := net.ParseIP(a.Address()).String()
+			if !dhcpv6ClientServerInPool(addr, tc["poolStart"].(string), clientCount) {
+				t.Fatalf("ERROR: DHCPv6 client %s leased %s outside of pool\n", i.DhcpClientName(), addr)
+			}
+			/* client may report remaining lease time */
+			if a.HasLeaseTime() && (a.LeaseTime() == 0 || a.LeaseTime() > tc["leaseTime"].(uint32)) {
+				t.Fatalf("ERROR: DHCPv6 client %s lease time %d not in (0, %d]\n", i.DhcpClientName(), a.LeaseTime(), tc["leaseTime"].(uint32))
+			}
+			if c, ok := leased[addr]; ok {
+				t.Fatalf("ERROR: DHCPv6 clients %s and %s leased same address %s\n", c, i.DhcpClientName(), addr)
+			}
+			leased[addr] = i.DhcpClientName()
+		}
+	}
+
+	return leased
+}
+
+func dhcpv6ClientServerLeasesOk(api *otg.OtgApi, tc map[string]interface{}, leased map[string]string) {
+	t := api.Testing()
+
+	count := 0
+	for _, s := range api.GetDhcpv6Leases() {
+		if s.DhcpServerName() != "dsDhcpv6" {
+			continue
+		}
+		for _, l := range s.Leases().Items() {
+			addr := net.ParseIP(l.Address()).String()
+			if _, ok := leased[addr]; !ok {
+				t.Fatalf("ERROR: DHCPv6 server lease %s not held by any client\n", addr)
+			}
+			if l.HasValidTime() && l.ValidTime() > tc["leaseTime"].(uint32) {
+				t.Fatalf("ERROR: DHCPv6 server lease %s valid time %d > %d\n", addr, l.ValidTime(), tc["leaseTime"].(uint32))
+			}
+			count += 1
+		}
+	}
+
+	if count != len(leased) {
+		t.Fatalf("ERROR: DHCPv6 server leases %d != client leases %d\n", count, len(leased))
+	}
+}
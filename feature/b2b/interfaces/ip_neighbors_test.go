@@ -10,7 +10,6 @@ import (
 )
 
 func TestIpNeighbors(t *testing.T) {
-	// TODO: add support for IPv6 as well
 	testConst := map[string]interface{}{
 		"pktRate":   uint64(50),
 		"pktCount":  uint32(100),
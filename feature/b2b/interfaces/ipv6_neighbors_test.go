@@ -0,0 +1,281 @@
+//go:build all || cpdp
+
+package interfaces
+
+import (
+	"net"
+	"testing"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func ipv6NeighborsTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktRate":   uint64(50),
+		"pktCount":  uint32(100),
+		"pktSize":   uint32(128),
+		"txMac":     "00:00:01:01:01:01",
+		"txIp":      "2000::1",
+		"txGateway": "2000::2",
+		"txPrefix":  uint32(64),
+		"rxMac":     "00:00:01:01:01:02",
+		"rxIp":      "2000::2",
+		"rxGateway": "2000::1",
+		"rxPrefix":  uint32(64),
+	}
+}
+
+func TestIpv6Neighbors(t *testing.T) {
+	testConst := ipv6NeighborsTestConst()
+
+	ipv6NeighborsTest(t, testConst)
+}
+
+func TestIpv6NeighborsLinkLocalGateway(t *testing.T) {
+	testConst := ipv6NeighborsTestConst()
+	/* gateways are link local addresses derived from peer MAC (modified EUI-64) */
+	testConst["txGateway"] = ipv6NeighborsLinkLocal(testConst["rxMac"].(string))
+	testConst["rxGateway"] = ipv6NeighborsLinkLocal(testConst["txMac"].(string))
+
+	ipv6NeighborsTest(t, testConst)
+}
+
+func ipv6NeighborsTest(t *testing.T, testConst map[string]interface{}) {
+	api := otg.NewOtgApi(t)
+	c := ipv6NeighborsConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	/* protocols are started after capture so that DAD and address resolution get captured */
+	api.StartCapture()
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return ipv6NeighborsIpv6NeighborsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForIpv6Neighbors"},
+	)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return flowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+
+	api.StopCapture()
+
+	ipv6NeighborsCaptureOk(api, c, testConst)
+}
+
+func ipv6NeighborsConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	if api.TestConfig().OtgCaptureCheck {
+		c.Captures().Add().
+			SetName("ca").
+			SetPortNames([]string{ptx.Name(), prx.Name()}).
+			SetFormat(gosnappi.CaptureFormat.PCAP)
+	}
+
+	dtx := c.Devices().Add().SetName("dtx")
+	drx := c.Devices().Add().SetName("drx")
+
+	dtxEth := dtx.Ethernets().
+		Add().
+		SetName("dtxEth").
+		SetMac(tc["txMac"].(string)).
+		SetMtu(1500)
+
+	dtxEth.Connection().SetPortName(ptx.Name())
+
+	dtxIp := dtxEth.
+		Ipv6Addresses().
+		Add().
+		SetName("dtxIp").
+		SetAddress(tc["txIp"].(string)).
+		SetGateway(tc["txGateway"].(string)).
+		SetPrefix(tc["txPrefix"].(uint32))
+
+	drxEth := drx.Ethernets().
+		Add().
+		SetName("drxEth").
+		SetMac(tc["rxMac"].(string)).
+		SetMtu(1500)
+
+	drxEth.Connection().SetPortName(prx.Name())
+
+	drxIp := drxEth.
+		Ipv6Addresses().
+		Add().
+		SetName("drxIp").
+		SetAddress(tc["rxIp"].(string)).
+		SetGateway(tc["rxGateway"].(string)).
+		SetPrefix(tc["rxPrefix"].(uint32))
+
+	flow := c.Flows().Add()
+	flow.SetName("ftxV6")
+	flow.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+	flow.Rate().SetPps(tc["pktRate"].(uint64))
+	flow.Size().SetFixed(tc["pktSize"].(uint32))
+	flow.Metrics().SetEnable(true)
+
+	flow.TxRx().Device().
+		SetTxNames([]string{dtxIp.Name()}).
+		SetRxNames([]string{drxIp.Name()})
+
+	/* destination MAC is auto filled in from resolved gateway */
+	ftxV6Eth := flow.Packet().Add().Ethernet()
+	ftxV6Eth.Src().SetValue(dtxEth.Mac())
+
+	ftxV6Ip := flow.Packet().Add().Ipv6()
+	ftxV6Ip.Src().SetValue(tc["txIp"].(string))
+	ftxV6Ip.Dst().SetValue(tc["rxIp"].(string))
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func ipv6NeighborsIpv6NeighborsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	count := 0
+	for _, n := range api.GetIpv6Neighbors() {
+		if !n.HasLinkLayerAddress() {
+			continue
+		}
+		for _, e := range []struct {
+			ethName string
+			gateway string
+			mac     string
+		}{
+			{"dtxEth", tc["txGateway"].(string), tc["rxMac"].(string)},
+			{"drxEth", tc["rxGateway"].(string), tc["txMac"].(string)},
+		} {
+			if n.EthernetName() == e.ethName &&
+				net.ParseIP(n.Ipv6Address()).Equal(net.ParseIP(e.gateway)) &&
+				n.LinkLayerAddress() == e.mac {
+				count += 1
+			}
+		}
+	}
+
+	return count == 2
+}
+
+// ipv6NeighborsLinkLocal returns link local address with interface ID derived
+// from mac as per modified EUI-64
+func ipv6NeighborsLinkLocal(mac string) string {
+	hw, _ := net.ParseMAC(mac)
+	ip := make(net.IP, net.IPv6len)
+	ip[0], ip[1] = 0xfe, 0x80
+	copy(ip[8:11], hw[0:3])
+	ip[8] ^= 0x02
+	ip[11], ip[12] = 0xff, 0xfe
+	copy(ip[13:16], hw[3:6])
+
+	return ip.String()
+}
+
+// ipv6NeighborsSolicitedNode returns solicited-node multicast address of ip
+func ipv6NeighborsSolicitedNode(ip string) []byte {
+	addr := net.ParseIP("ff02::1:ff00:0")
+	copy(addr[13:16], net.ParseIP(ip).To16()[13:16])
+
+	return addr
+}
+
+func ipv6NeighborsCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]interface{}) {
+	if !api.TestConfig().OtgCaptureCheck {
+		return
+	}
+	t := api.Testing()
+
+	/* ICMPv6 ND messages: type 135 (NS) and 136 (NA), target address at offset 62 */
+	findNd := func(cPackets *otg.CapturedPackets, ethSrc string, icmpType uint64, ipSrc []byte, ipDst []byte, target string) int {
+		for i := 0; i < len(cPackets.Packets); i++ {
+			if cPackets.CheckField(i, 6, api.MacAddrToBytes(ethSrc)) != nil ||
+				cPackets.CheckField(i, 12, api.Uint64ToBytes(0x86dd, 2)) != nil ||
+				cPackets.CheckField(i, 20, api.Uint64ToBytes(58, 1)) != nil ||
+				cPackets.CheckField(i, 54, api.Uint64ToBytes(icmpType, 1)) != nil ||
+				cPackets.CheckField(i, 62, api.Ipv6AddrToBytes(target)) != nil {
+				continue
+			}
+			if ipSrc != nil && cPackets.CheckField(i, 22, ipSrc) != nil {
+				continue
+			}
+			if ipDst != nil && cPackets.CheckField(i, 38, ipDst) != nil {
+				continue
+			}
+			return i
+		}
+		return -1
+	}
+
+	ptxPackets := api.GetCapture(c.Ports().Items()[0].Name())
+	prxPackets := api.GetCapture(c.Ports().Items()[1].Name())
+
+	/* DAD: NS from unspecified address to solicited-node address of tentative address */
+	for _, e := range []struct {
+		cPackets *otg.CapturedPackets
+		mac      string
+		ip       string
+	}{
+		{prxPackets, tc["txMac"].(string), tc["txIp"].(string)},
+		{ptxPackets, tc["rxMac"].(string), tc["rxIp"].(string)},
+	} {
+		if findNd(e.cPackets, e.mac, 135, net.IPv6unspecified, ipv6NeighborsSolicitedNode(e.ip), e.ip) < 0 {
+			t.Fatalf("ERROR: DAD neighbor solicitation for %s not captured\n", e.ip)
+		}
+	}
+
+	/* address resolution: NS for gateway followed by solicited NA from gateway */
+	for _, e := range []struct {
+		nsPackets *otg.CapturedPackets
+		naPackets *otg.CapturedPackets
+		mac       string
+		peerMac   string
+		gateway   string
+	}{
+		{prxPackets, ptxPackets, tc["txMac"].(string), tc["rxMac"].(string), tc["txGateway"].(string)},
+		{ptxPackets, prxPackets, tc["rxMac"].(string), tc["txMac"].(string), tc["rxGateway"].(string)},
+	} {
+		if findNd(e.nsPackets, e.mac, 135, nil, ipv6NeighborsSolicitedNode(e.gateway), e.gateway) < 0 {
+			t.Fatalf("ERROR: neighbor solicitation for %s not captured\n", e.gateway)
+		}
+
+		i := findNd(e.naPackets, e.peerMac, 136, api.Ipv6AddrToBytes(e.gateway), nil, e.gateway)
+		if i < 0 {
+			t.Fatalf("ERROR: neighbor advertisement for %s not captured\n", e.gateway)
+		}
+		/* solicited flag needs to be set in response to NS */
+		if e.naPackets.Packets[i].Data[58]&0x40 == 0 {
+			t.Fatalf("ERROR: solicited flag not set in neighbor advertisement for %s\n", e.gateway)
+		}
+	}
+
+	/* data frames need to be sent to resolved gateway MAC */
+	expCount := int(tc["pktCount"].(uint32))
+	actCount := 0
+	for i := 0; i < len(prxPackets.Packets); i++ {
+		if !prxPackets.HasField(t, "ethernet src", i, 6, api.MacAddrToBytes(tc["txMac"].(string))) ||
+			prxPackets.CheckField(i, 20, api.Uint64ToBytes(58, 1)) == nil {
+			continue
+		}
+		prxPackets.ValidateSize(t, i, int(tc["pktSize"].(uint32)))
+		prxPackets.ValidateField(t, "ethernet dst", i, 0, api.MacAddrToBytes(tc["rxMac"].(string)))
+		prxPackets.ValidateField(t, "ethernet type", i, 12, api.Uint64ToBytes(0x86dd, 2))
+		prxPackets.ValidateField(t, "ipv6 src", i, 22, api.Ipv6AddrToBytes(tc["txIp"].(string)))
+		prxPackets.ValidateField(t, "ipv6 dst", i, 38, api.Ipv6AddrToBytes(tc["rxIp"].(string)))
+		actCount += 1
+	}
+	if expCount != actCount {
+		t.Fatalf("ERROR: expCount %d != actCount %d\n", expCount, actCount)
+	}
+}
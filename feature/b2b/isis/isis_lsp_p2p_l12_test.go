@@ -62,6 +62,12 @@ func TestIsisLspP2pL12(t *testing.T) {
 			Timeout: time.Duration(30) * time.Second},
 	)
 
+	api.WaitFor(
+		func() bool { return isisLspP2pL12IsisLspTlvsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForIsisLspTlvs",
+			Timeout: time.Duration(30) * time.Second},
+	)
+
 	api.StartTransmit()
 
 	api.WaitFor(
@@ -150,7 +156,7 @@ func isisLspP2pL12Config(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Co
 
 	dtxIsisRrV6.Addresses().Add().
 		SetAddress(tc["txAdvRouteV6"].(string)).
-		SetPrefix(32).
+		SetPrefix(128).
 		SetCount(tc["txRouteCount"].(uint32)).
 		SetStep(1)
 
@@ -220,7 +226,7 @@ func isisLspP2pL12Config(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Co
 
 	drxIsisRrV6.Addresses().Add().
 		SetAddress(tc["rxAdvRouteV6"].(string)).
-		SetPrefix(32).
+		SetPrefix(128).
 		SetCount(tc["rxRouteCount"].(uint32)).
 		SetStep(1)
 
@@ -323,6 +329,36 @@ func isisLspP2pL12IsisLspsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
 	return lspCount == 4
 }
 
+func isisLspP2pL12IsisLspTlvsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	t := api.Testing()
+	lsps := api.GetIsisLsps()
+
+	peers := map[string]string{"tx": "rx", "rx": "tx"}
+	for local, remote := range peers {
+		for _, pduType := range []gosnappi.IsisLspStatePduTypeEnum{
+			gosnappi.IsisLspStatePduType.LEVEL_1, gosnappi.IsisLspStatePduType.LEVEL_2,
+		} {
+			m := otg.IsisLspMatch{
+				RouterName:  fmt.Sprintf("d%sIsis", remote),
+				LspId:       fmt.Sprintf("%s-00-00", tc[local+"IsisSystemId"].(string)),
+				PduType:     pduType,
+				Hostname:    fmt.Sprintf("d%sIsis", local),
+				IsNeighbors: []string{tc[remote+"IsisSystemId"].(string)},
+				V4Prefixes: []otg.IsisPrefixMatch{
+					{Address: tc[local+"AdvRouteV4"].(string), PrefixLength: 32, Metric: 10},
+				},
+				V6Prefixes: []otg.IsisPrefixMatch{
+					{Address: tc[local+"AdvRouteV6"].(string), PrefixLength: 128, Metric: 10},
+				},
+			}
+			if !m.Has(t, lsps) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func isisLspP2pL12MetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
 	for _, m := range api.GetIsIsMetrics() {
 		if m.L1SessionsUp() < 1 || m.L2SessionsUp() < 1 ||
@@ -0,0 +1,355 @@
+//go:build all || cpdp
+
+package isis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+/* Demonstrates simulated ISIS topologies (grid, ring and tree) attached behind
+   an emulated router, which is peered with another emulated router over a
+   point-to-point L1/L2 adjacency.
+   Each simulated router originates exactly one LSP per level, hence L1 and L2
+   database size of both emulated routers shall be number of simulated routers
+   plus two, and traffic destined to prefixes advertised by simulated routers
+   shall be forwarded. */
+
+func isisSimulatedTopologyTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktRate":           uint64(50),
+		"pktCount":          uint32(100),
+		"pktSize":           uint32(128),
+		"txMac":             "00:00:01:01:01:01",
+		"txIp":              "1.1.1.1",
+		"txGateway":         "1.1.1.2",
+		"txPrefix":          uint32(24),
+		"txIpv6":            "1100::1",
+		"txv6Gateway":       "1100::2",
+		"txv6Prefix":        uint32(64),
+		"txIsisSystemId":    "640000000001",
+		"txIsisAreaAddress": []string{"490001"},
+		"rxMac":             "00:00:01:01:01:02",
+		"rxIp":              "1.1.1.2",
+		"rxGateway":         "1.1.1.1",
+		"rxPrefix":          uint32(24),
+		"rxIpv6":            "1100::2",
+		"rxv6Gateway":       "1100::1",
+		"rxv6Prefix":        uint32(64),
+		"rxIsisSystemId":    "650000000001",
+		"rxIsisAreaAddress": []string{"490001"},
+		"rxRouteCount":      uint32(1),
+		"rxAdvRouteV4":      "20.20.20.1",
+		"rxAdvRouteV6":      "::20:20:20:1",
+		"simSystemId":       "660000000001",
+		"simMac":            "00:00:02:00:00:01",
+		"simLinkIp":         "30.0.0.0",
+		"simRouteV4":        "40.0.0.0",
+		"simRouteV6":        "4000::",
+		"simRoutesPerNode":  uint32(4),
+	}
+}
+
+func TestIsisSimulatedTopologyGrid(t *testing.T) {
+	isisSimulatedTopologyTest(t, otg.IsisTopologySpec{
+		Type: otg.IsisTopologyGrid,
+		Rows: 3,
+		Cols: 3,
+	})
+}
+
+func TestIsisSimulatedTopologyRing(t *testing.T) {
+	isisSimulatedTopologyTest(t, otg.IsisTopologySpec{
+		Type:      otg.IsisTopologyRing,
+		NodeCount: 6,
+	})
+}
+
+func TestIsisSimulatedTopologyTree(t *testing.T) {
+	isisSimulatedTopologyTest(t, otg.IsisTopologySpec{
+		Type:      otg.IsisTopologyTree,
+		NodeCount: 7,
+		Fanout:    2,
+	})
+}
+
+func isisSimulatedTopologyTest(t *testing.T, spec otg.IsisTopologySpec) {
+	testConst := isisSimulatedTopologyTestConst()
+
+	api := otg.NewOtgApi(t)
+	c, topo := isisSimulatedTopologyConfig(api, testConst, spec)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	/* Check if adjacencies are up and LSPs of all simulated routers are learnt */
+	api.WaitFor(
+		func() bool { return isisSimulatedTopologyMetricsOk(api, topo) },
+		&otg.WaitForOpts{FnName: "WaitForIsisMetrics", Timeout: 60 * time.Second},
+	)
+
+	api.WaitFor(
+		func() bool { return isisSimulatedTopologyIsisLspsOk(api, testConst, topo) },
+		&otg.WaitForOpts{FnName: "WaitForIsisLsps", Timeout: 30 * time.Second},
+	)
+
+	api.StartTransmit()
+
+	/* Check if traffic to prefixes inside simulated topology is forwarded */
+	api.WaitFor(
+		func() bool { return isisSimulatedTopologyFlowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+}
+
+func isisSimulatedTopologyConfig(api *otg.OtgApi, tc map[string]interface{}, spec otg.IsisTopologySpec) (gosnappi.Config, *otg.IsisTopology) {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	dtx := c.Devices().Add().SetName("dtx")
+	drx := c.Devices().Add().SetName("drx")
+
+	// transmit
+	dtxEth := dtx.Ethernets().
+		Add().
+		SetName("dtxEth").
+		SetMac(tc["txMac"].(string)).
+		SetMtu(1500)
+
+	dtxEth.Connection().SetPortName(ptx.Name())
+
+	dtxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("dtxIp").
+		SetAddress(tc["txIp"].(string)).
+		SetGateway(tc["txGateway"].(string)).
+		SetPrefix(tc["txPrefix"].(uint32))
+
+	dtxEth.
+		Ipv6Addresses().
+		Add().
+		SetName("dtxIpv6").
+		SetAddress(tc["txIpv6"].(string)).
+		SetGateway(tc["txv6Gateway"].(string)).
+		SetPrefix(tc["txv6Prefix"].(uint32))
+
+	dtxIsis := dtx.Isis().
+		SetSystemId(tc["txIsisSystemId"].(string)).
+		SetName("dtxIsis")
+
+	dtxIsis.Basic().
+		SetIpv4TeRouterId(tc["txIp"].(string)).
+		SetHostname(dtxIsis.Name()).
+		SetLearnedLspFilter(true)
+
+	dtxIsis.Advanced().
+		SetAreaAddresses(tc["txIsisAreaAddress"].([]string)).
+		SetLspRefreshRate(900).
+		SetEnableAttachedBit(false)
+
+	dtxIsis.Interfaces().
+		Add().
+		SetEthName(dtxEth.Name()).
+		SetName("dtxIsisInt").
+		SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+		SetLevelType(gosnappi.IsisInterfaceLevelType.LEVEL_1_2).
+		L2Settings().
+		SetDeadInterval(30).
+		SetHelloInterval(10).
+		SetPriority(0)
+
+	// simulated topology behind transmit router
+	spec.Name = "sim"
+	spec.StartSystemId = tc["simSystemId"].(string)
+	spec.AreaAddresses = tc["txIsisAreaAddress"].([]string)
+	spec.LevelType = gosnappi.IsisInterfaceLevelType.LEVEL_1_2
+	spec.StartMac = tc["simMac"].(string)
+	spec.StartLinkIpv4 = tc["simLinkIp"].(string)
+	spec.StartRouteV4 = tc["simRouteV4"].(string)
+	spec.RouteV4Prefix = 24
+	spec.StartRouteV6 = tc["simRouteV6"].(string)
+	spec.RouteV6Prefix = 64
+	spec.RoutesPerNode = tc["simRoutesPerNode"].(uint32)
+
+	topo := api.AddIsisTopology(c, dtx, spec)
+
+	// recieve
+	drxEth := drx.Ethernets().
+		Add().
+		SetName("drxEth").
+		SetMac(tc["rxMac"].(string)).
+		SetMtu(1500)
+
+	drxEth.Connection().SetPortName(prx.Name())
+
+	drxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("drxIp").
+		SetAddress(tc["rxIp"].(string)).
+		SetGateway(tc["rxGateway"].(string)).
+		SetPrefix(tc["rxPrefix"].(uint32))
+
+	drxEth.
+		Ipv6Addresses().
+		Add().
+		SetName("drxIpv6").
+		SetAddress(tc["rxIpv6"].(string)).
+		SetGateway(tc["rxv6Gateway"].(string)).
+		SetPrefix(tc["rxv6Prefix"].(uint32))
+
+	drxIsis := drx.Isis().
+		SetSystemId(tc["rxIsisSystemId"].(string)).
+		SetName("drxIsis")
+
+	drxIsis.Basic().
+		SetIpv4TeRouterId(tc["rxIp"].(string)).
+		SetHostname(drxIsis.Name()).
+		SetLearnedLspFilter(true)
+
+	drxIsis.Advanced().
+		SetAreaAddresses(tc["rxIsisAreaAddress"].([]string)).
+		SetLspRefreshRate(900).
+		SetEnableAttachedBit(false)
+
+	drxIsis.Interfaces().
+		Add().
+		SetEthName(drxEth.Name()).
+		SetName("drxIsisInt").
+		SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+		SetLevelType(gosnappi.IsisInterfaceLevelType.LEVEL_1_2).
+		L2Settings().
+		SetDeadInterval(30).
+		SetHelloInterval(10).
+		SetPriority(0)
+
+	drxIsisRrV4 := drxIsis.
+		V4Routes().
+		Add().SetName("drxIsisRr4").SetLinkMetric(10)
+
+	drxIsisRrV4.Addresses().Add().
+		SetAddress(tc["rxAdvRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(tc["rxRouteCount"].(uint32)).
+		SetStep(1)
+
+	drxIsisRrV6 := drxIsis.
+		V6Routes().
+		Add().SetName("drxIsisRr6").SetLinkMetric(10)
+
+	drxIsisRrV6.Addresses().Add().
+		SetAddress(tc["rxAdvRouteV6"].(string)).
+		SetPrefix(128).
+		SetCount(tc["rxRouteCount"].(uint32)).
+		SetStep(1)
+
+	for i := 1; i <= 2; i++ {
+		flow := c.Flows().Add()
+		flow.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+		flow.Rate().SetPps(tc["pktRate"].(uint64))
+		flow.Size().SetFixed(tc["pktSize"].(uint32))
+		flow.Metrics().SetEnable(true)
+	}
+
+	frxV4 := c.Flows().Items()[0]
+	frxV4.SetName("frxV4")
+	frxV4.TxRx().Device().
+		SetTxNames([]string{drxIsisRrV4.Name()}).
+		SetRxNames(topo.V4RouteNames())
+
+	frxV4Eth := frxV4.Packet().Add().Ethernet()
+	frxV4Eth.Src().SetValue(drxEth.Mac())
+
+	frxV4Ip := frxV4.Packet().Add().Ipv4()
+	frxV4Ip.Src().SetValue(tc["rxAdvRouteV4"].(string))
+	frxV4Ip.Dst().SetValues(topo.V4Addresses())
+
+	frxV4Tcp := frxV4.Packet().Add().Tcp()
+	frxV4Tcp.SrcPort().SetValue(6000)
+	frxV4Tcp.DstPort().SetValue(5000)
+
+	frxV6 := c.Flows().Items()[1]
+	frxV6.SetName("frxV6")
+	frxV6.TxRx().Device().
+		SetTxNames([]string{drxIsisRrV6.Name()}).
+		SetRxNames(topo.V6RouteNames())
+
+	frxV6Eth := frxV6.Packet().Add().Ethernet()
+	frxV6Eth.Src().SetValue(drxEth.Mac())
+
+	frxV6Ip := frxV6.Packet().Add().Ipv6()
+	frxV6Ip.Src().SetValue(tc["rxAdvRouteV6"].(string))
+	frxV6Ip.Dst().SetValues(topo.V6Addresses())
+
+	frxV6Tcp := frxV6.Packet().Add().Tcp()
+	frxV6Tcp.SrcPort().SetValue(6000)
+	frxV6Tcp.DstPort().SetValue(5000)
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c, topo
+}
+
+func isisSimulatedTopologyMetricsOk(api *otg.OtgApi, topo *otg.IsisTopology) bool {
+	dbSize := uint64(topo.NodeCount() + 2)
+	count := 0
+	for _, m := range api.GetIsIsMetrics() {
+		if m.Name() != "dtxIsis" && m.Name() != "drxIsis" {
+			continue
+		}
+		if m.L1SessionsUp() < 1 || m.L2SessionsUp() < 1 ||
+			m.L1DatabaseSize() != dbSize || m.L2DatabaseSize() != dbSize {
+			return false
+		}
+		count += 1
+	}
+	return count == 2
+}
+
+func isisSimulatedTopologyIsisLspsOk(api *otg.OtgApi, tc map[string]interface{}, topo *otg.IsisTopology) bool {
+	expected := map[string]bool{
+		tc["txIsisSystemId"].(string) + "-00-00": true,
+	}
+	for _, n := range topo.Nodes {
+		expected[n.SystemId+"-00-00"] = true
+	}
+
+	lspCount := 0
+	for _, m := range api.GetIsisLsps() {
+		if m.IsisRouterName() != "drxIsis" {
+			continue
+		}
+		for _, l := range m.Lsps().Items() {
+			if expected[l.LspId()] {
+				lspCount += 1
+			}
+		}
+	}
+	/* each LSP is learnt at both L1 and L2 */
+	return lspCount == 2*len(expected)
+}
+
+func isisSimulatedTopologyFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	pktCount := uint64(tc["pktCount"].(uint32))
+
+	for _, m := range api.GetFlowMetrics() {
+		if m.Transmit() != gosnappi.FlowMetricTransmit.STOPPED ||
+			m.FramesTx() != pktCount ||
+			m.FramesRx() != pktCount {
+			return false
+		}
+	}
+
+	return true
+}
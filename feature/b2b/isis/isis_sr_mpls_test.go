@@ -0,0 +1,393 @@
+//go:build all || cpdp
+
+package isis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+/* Demonstrates SR-MPLS over ISIS, where each emulated router advertises SR
+   capability with an SRGB, a prefix SID (as index) for its loopback route and
+   an adjacency SID (as label) for its point-to-point adjacency.
+   Advertised SIDs are validated via ISIS LSP state of the peer, and MPLS
+   labeled traffic is sent using the label stack derived from these SIDs,
+   i.e. SRGB base + prefix SID index with or without adjacency SID on top.
+   Capture on receiving port validates the label stack of each packet. */
+
+func TestIsisSrMpls(t *testing.T) {
+	testConst := map[string]interface{}{
+		"pktRate":           uint64(50),
+		"pktCount":          uint32(100),
+		"pktSize":           uint32(128),
+		"txMac":             "00:00:01:01:01:01",
+		"txIp":              "1.1.1.1",
+		"txGateway":         "1.1.1.2",
+		"txPrefix":          uint32(24),
+		"txIsisSystemId":    "640000000001",
+		"txIsisAreaAddress": []string{"490001"},
+		"rxMac":             "00:00:01:01:01:02",
+		"rxIp":              "1.1.1.2",
+		"rxGateway":         "1.1.1.1",
+		"rxPrefix":          uint32(24),
+		"rxIsisSystemId":    "650000000001",
+		"rxIsisAreaAddress": []string{"490001"},
+		"txAdvRouteV4":      "10.10.10.1",
+		"rxAdvRouteV4":      "20.20.20.1",
+		"srgbStart":         uint32(16000),
+		"srgbRange":         uint32(8000),
+		"txPrefixSidIndex":  uint32(1),
+		"rxPrefixSidIndex":  uint32(2),
+		"txAdjSid":          uint32(24001),
+		"rxAdjSid":          uint32(24002),
+		"mplsTtl":           uint32(64),
+	}
+
+	api := otg.NewOtgApi(t)
+	c := isisSrMplsConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return isisSrMplsMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForIsisMetrics",
+			Timeout: time.Duration(30) * time.Second},
+	)
+
+	/* Check if SR capability, prefix SIDs and adjacency SIDs are learnt by peer */
+	api.WaitFor(
+		func() bool { return isisSrMplsIsisLspsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForIsisLspSids",
+			Timeout: time.Duration(30) * time.Second},
+	)
+
+	api.StartCapture()
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return isisSrMplsFlowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+
+	api.StopCapture()
+
+	isisSrMplsCaptureOk(api, c, testConst)
+}
+
+func isisSrMplsConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	if api.TestConfig().OtgCaptureCheck {
+		c.Captures().Add().
+			SetName("ca").
+			SetPortNames([]string{prx.Name()}).
+			SetFormat(gosnappi.CaptureFormat.PCAP)
+	}
+
+	dtx := c.Devices().Add().SetName("dtx")
+	drx := c.Devices().Add().SetName("drx")
+
+	// transmit
+	dtxEth := dtx.Ethernets().
+		Add().
+		SetName("dtxEth").
+		SetMac(tc["txMac"].(string)).
+		SetMtu(1500)
+
+	dtxEth.Connection().SetPortName(ptx.Name())
+
+	dtxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("dtxIp").
+		SetAddress(tc["txIp"].(string)).
+		SetGateway(tc["txGateway"].(string)).
+		SetPrefix(tc["txPrefix"].(uint32))
+
+	dtxIsis := dtx.Isis().
+		SetSystemId(tc["txIsisSystemId"].(string)).
+		SetName("dtxIsis")
+
+	dtxIsis.Basic().
+		SetIpv4TeRouterId(tc["txIp"].(string)).
+		SetHostname(dtxIsis.Name()).
+		SetLearnedLspFilter(true)
+
+	dtxIsis.Advanced().
+		SetAreaAddresses(tc["txIsisAreaAddress"].([]string)).
+		SetLspRefreshRate(900).
+		SetEnableAttachedBit(false)
+
+	dtxIsisCap := dtxIsis.SegmentRouting().RouterCapability()
+	dtxIsisCap.Ipv4TeRouterId()
+	dtxIsisCap.SrCapability().Flags().SetIpv4Mpls(true)
+	dtxIsisCap.SrCapability().SrgbRanges().Add().
+		SetStartingSid(tc["srgbStart"].(uint32)).
+		SetRange(tc["srgbRange"].(uint32))
+
+	dtxIsisInt := dtxIsis.Interfaces().
+		Add().
+		SetEthName(dtxEth.Name()).
+		SetName("dtxIsisInt").
+		SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+		SetLevelType(gosnappi.IsisInterfaceLevelType.LEVEL_2)
+
+	dtxIsisInt.AdjacencySids().Add().
+		SetSidValues([]uint32{tc["txAdjSid"].(uint32)})
+
+	dtxIsisRrV4 := dtxIsis.
+		V4Routes().
+		Add().SetName("dtxIsisRr4").SetLinkMetric(10)
+
+	dtxIsisRrV4.Addresses().Add().
+		SetAddress(tc["txAdvRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(1).
+		SetStep(1)
+
+	dtxIsisRrV4.PrefixSids().Add().
+		SetSidIndices([]uint32{tc["txPrefixSidIndex"].(uint32)}).
+		SetNFlag(true)
+
+	// recieve
+	drxEth := drx.Ethernets().
+		Add().
+		SetName("drxEth").
+		SetMac(tc["rxMac"].(string)).
+		SetMtu(1500)
+
+	drxEth.Connection().SetPortName(prx.Name())
+
+	drxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("drxIp").
+		SetAddress(tc["rxIp"].(string)).
+		SetGateway(tc["rxGateway"].(string)).
+		SetPrefix(tc["rxPrefix"].(uint32))
+
+	drxIsis := drx.Isis().
+		SetSystemId(tc["rxIsisSystemId"].(string)).
+		SetName("drxIsis")
+
+	drxIsis.Basic().
+		SetIpv4TeRouterId(tc["rxIp"].(string)).
+		SetHostname(drxIsis.Name()).
+		SetLearnedLspFilter(true)
+
+	drxIsis.Advanced().
+		SetAreaAddresses(tc["rxIsisAreaAddress"].([]string)).
+		SetLspRefreshRate(900).
+		SetEnableAttachedBit(false)
+
+	drxIsisCap := drxIsis.SegmentRouting().RouterCapability()
+	drxIsisCap.Ipv4TeRouterId()
+	drxIsisCap.SrCapability().Flags().SetIpv4Mpls(true)
+	drxIsisCap.SrCapability().SrgbRanges().Add().
+		SetStartingSid(tc["srgbStart"].(uint32)).
+		SetRange(tc["srgbRange"].(uint32))
+
+	drxIsisInt := drxIsis.Interfaces().
+		Add().
+		SetEthName(drxEth.Name()).
+		SetName("drxIsisInt").
+		SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+		SetLevelType(gosnappi.IsisInterfaceLevelType.LEVEL_2)
+
+	drxIsisInt.AdjacencySids().Add().
+		SetSidValues([]uint32{tc["rxAdjSid"].(uint32)})
+
+	drxIsisRrV4 := drxIsis.
+		V4Routes().
+		Add().SetName("drxIsisRr4").SetLinkMetric(10)
+
+	drxIsisRrV4.Addresses().Add().
+		SetAddress(tc["rxAdvRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(1).
+		SetStep(1)
+
+	drxIsisRrV4.PrefixSids().Add().
+		SetSidIndices([]uint32{tc["rxPrefixSidIndex"].(uint32)}).
+		SetNFlag(true)
+
+	for i := 1; i <= 2; i++ {
+		flow := c.Flows().Add()
+		flow.TxRx().Device().
+			SetTxNames([]string{dtxIsisRrV4.Name()}).
+			SetRxNames([]string{drxIsisRrV4.Name()})
+		flow.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+		flow.Rate().SetPps(tc["pktRate"].(uint64))
+		flow.Size().SetFixed(tc["pktSize"].(uint32))
+		flow.Metrics().SetEnable(true)
+	}
+
+	// label stack of each flow, top of stack first
+	labelStacks := isisSrMplsLabelStacks(tc)
+
+	for i, f := range c.Flows().Items() {
+		f.SetName(fmt.Sprintf("f%d", i+1))
+
+		eth := f.Packet().Add().Ethernet()
+		eth.Src().SetValue(dtxEth.Mac())
+		eth.Dst().SetValue(drxEth.Mac())
+
+		stack := labelStacks[i]
+		for j, label := range stack {
+			mpls := f.Packet().Add().Mpls()
+			mpls.Label().SetValue(label)
+			mpls.TrafficClass().SetValue(0)
+			mpls.TimeToLive().SetValue(tc["mplsTtl"].(uint32))
+			if j == len(stack)-1 {
+				mpls.BottomOfStack().SetValue(1)
+			} else {
+				mpls.BottomOfStack().SetValue(0)
+			}
+		}
+
+		ip := f.Packet().Add().Ipv4()
+		ip.Src().SetValue(tc["txAdvRouteV4"].(string))
+		ip.Dst().SetValue(tc["rxAdvRouteV4"].(string))
+
+		udp := f.Packet().Add().Udp()
+		udp.SrcPort().SetValue(5000)
+		udp.DstPort().SetValue(6000)
+	}
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func isisSrMplsLabelStacks(tc map[string]interface{}) [][]uint32 {
+	rxPrefixLabel := tc["srgbStart"].(uint32) + tc["rxPrefixSidIndex"].(uint32)
+	return [][]uint32{
+		// steer to rx prefix using its prefix SID
+		{rxPrefixLabel},
+		// steer over tx adjacency, then to rx prefix
+		{tc["txAdjSid"].(uint32), rxPrefixLabel},
+	}
+}
+
+func isisSrMplsMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	for _, m := range api.GetIsIsMetrics() {
+		if m.L2SessionsUp() < 1 || m.L2DatabaseSize() < 2 {
+			return false
+		}
+	}
+	return true
+}
+
+func isisSrMplsIsisLspsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	t := api.Testing()
+	lsps := api.GetIsisLsps()
+
+	peers := map[string]string{"tx": "rx", "rx": "tx"}
+	for local, remote := range peers {
+		m := otg.IsisLspMatch{
+			RouterName:  fmt.Sprintf("d%sIsis", remote),
+			LspId:       fmt.Sprintf("%s-00-00", tc[local+"IsisSystemId"].(string)),
+			PduType:     gosnappi.IsisLspStatePduType.LEVEL_2,
+			IsNeighbors: []string{tc[remote+"IsisSystemId"].(string)},
+			V4Prefixes: []otg.IsisPrefixMatch{
+				{
+					Address:      tc[local+"AdvRouteV4"].(string),
+					PrefixLength: 32,
+					Metric:       10,
+					PrefixSids:   []uint32{tc[local+"PrefixSidIndex"].(uint32)},
+				},
+			},
+			RouterCapId: tc[local+"Ip"].(string),
+			SrgbRanges: []otg.IsisSrgbRange{
+				{StartingSid: tc["srgbStart"].(uint32), Range: tc["srgbRange"].(uint32)},
+			},
+			AdjacencySids: []uint32{tc[local+"AdjSid"].(uint32)},
+		}
+		if !m.Has(t, lsps) {
+			return false
+		}
+	}
+	return true
+}
+
+func isisSrMplsFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	pktCount := uint64(tc["pktCount"].(uint32))
+
+	for _, m := range api.GetFlowMetrics() {
+		if m.Transmit() != gosnappi.FlowMetricTransmit.STOPPED ||
+			m.FramesTx() != pktCount ||
+			m.FramesRx() != pktCount {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isisSrMplsCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]interface{}) {
+	if !api.TestConfig().OtgCaptureCheck {
+		return
+	}
+	t := api.Testing()
+	cPackets := api.GetCapture(c.Ports().Items()[1].Name())
+
+	// mpls header is label (20 bits), traffic class (3 bits), bottom of stack (1 bit) and ttl (8 bits)
+	mplsHeader := func(label uint32, bos uint32) []byte {
+		return api.Uint64ToBytes(uint64(label<<12|bos<<8|tc["mplsTtl"].(uint32)), 4)
+	}
+
+	labelStacks := isisSrMplsLabelStacks(tc)
+	counts := make([]int, len(labelStacks))
+
+	for i := 0; i < len(cPackets.Packets); i++ {
+		// ignore unexpected packets based on ethernet src MAC and ethernet type
+		if cPackets.CheckField(i, 6, api.MacAddrToBytes(tc["txMac"].(string))) != nil ||
+			cPackets.CheckField(i, 12, api.Uint64ToBytes(0x8847, 2)) != nil {
+			continue
+		}
+
+		for s, stack := range labelStacks {
+			matched := true
+			for j, label := range stack {
+				bos := uint32(0)
+				if j == len(stack)-1 {
+					bos = 1
+				}
+				if cPackets.CheckField(i, 14+4*j, mplsHeader(label, bos)) != nil {
+					matched = false
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+
+			ipOffset := 14 + 4*len(stack)
+			cPackets.ValidateSize(t, i, int(tc["pktSize"].(uint32)))
+			cPackets.ValidateField(t, "ipv4 src", i, ipOffset+12, api.Ipv4AddrToBytes(tc["txAdvRouteV4"].(string)))
+			cPackets.ValidateField(t, "ipv4 dst", i, ipOffset+16, api.Ipv4AddrToBytes(tc["rxAdvRouteV4"].(string)))
+			counts[s] += 1
+			break
+		}
+	}
+
+	expCount := int(tc["pktCount"].(uint32))
+	for s, actCount := range counts {
+		if expCount != actCount {
+			t.Fatalf("ERROR: label stack %v: expCount %d != actCount %d\n", labelStacks[s], expCount, actCount)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+//go:build all || dp
+
+package link
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func portLinkTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktRate":   uint64(100),
+		"pktSize":   uint32(128),
+		"txMac":     "00:00:01:01:01:01",
+		"rxMac":     "00:00:01:01:01:02",
+		"txIp":      "1.1.1.1",
+		"rxIp":      "1.1.1.2",
+		"txUdpPort": uint32(5000),
+		"rxUdpPort": uint32(6000),
+		/* time for which traffic is observed after each link change */
+		"window": 2 * time.Second,
+	}
+}
+
+func TestPortLinkDownTx(t *testing.T) {
+	testConst := portLinkTestConst()
+	testConst["downPort"] = "p1"
+
+	portLinkTest(otg.NewOtgApi(t), testConst)
+}
+
+func TestPortLinkDownRx(t *testing.T) {
+	testConst := portLinkTestConst()
+	testConst["downPort"] = "p2"
+
+	portLinkTest(otg.NewOtgApi(t), testConst)
+}
+
+func portLinkTest(api *otg.OtgApi, testConst map[string]interface{}) {
+	downPort := testConst["downPort"].(string)
+	linksUp := map[string]gosnappi.PortMetricLinkEnum{
+		"p1": gosnappi.PortMetricLink.UP,
+		"p2": gosnappi.PortMetricLink.UP,
+	}
+	linkDown := map[string]gosnappi.PortMetricLinkEnum{
+		downPort: gosnappi.PortMetricLink.DOWN,
+	}
+
+	c := portLinkConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.WaitFor(
+		func() bool { return api.PortLinksOk(linksUp) },
+		&otg.WaitForOpts{FnName: "WaitForPortLinksUp"},
+	)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return api.GetFlowMetrics()[0].FramesRx() > 0 },
+		&otg.WaitForOpts{FnName: "WaitForFlowFramesRx"},
+	)
+
+	api.SetPortLinksDown([]string{downPort})
+
+	api.WaitFor(
+		func() bool { return api.PortLinksOk(linkDown) },
+		&otg.WaitForOpts{FnName: "WaitForPortLinkDown"},
+	)
+
+	/* no frames are expected to be received while link is down, irrespective
+	   of whether link is brought down on transmitting or receiving port */
+	if frames := api.FlowFramesRxOver(testConst["window"].(time.Duration))["f1"]; frames != 0 {
+		api.Testing().Fatalf("ERROR: %d frames received while link on %s is down\n", frames, downPort)
+	}
+
+	api.SetPortLinksUp([]string{downPort})
+
+	api.WaitFor(
+		func() bool { return api.PortLinksOk(linksUp) },
+		&otg.WaitForOpts{FnName: "WaitForPortLinksUp"},
+	)
+
+	/* flow is expected to be delivered again once link is restored */
+	api.WaitFor(
+		func() bool { return api.FlowFramesRxOver(testConst["window"].(time.Duration))["f1"] > 0 },
+		&otg.WaitForOpts{FnName: "WaitForFlowFramesRx", Timeout: 30 * time.Second},
+	)
+
+	api.StopTransmit()
+}
+
+func portLinkConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+	p1 := c.Ports().Add().SetName("p1").SetLocation(api.TestConfig().OtgPorts[0])
+	p2 := c.Ports().Add().SetName("p2").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{p1.Name(), p2.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	f1 := c.Flows().Add().SetName("f1")
+	f1.TxRx().Port().
+		SetTxName(p1.Name()).
+		SetRxNames([]string{p2.Name()})
+	f1.Duration().Continuous()
+	f1.Rate().SetPps(tc["pktRate"].(uint64))
+	f1.Size().SetFixed(tc["pktSize"].(uint32))
+	f1.Metrics().SetEnable(true)
+
+	eth := f1.Packet().Add().Ethernet()
+	eth.Src().SetValue(tc["txMac"].(string))
+	eth.Dst().SetValue(tc["rxMac"].(string))
+
+	ip := f1.Packet().Add().Ipv4()
+	ip.Src().SetValue(tc["txIp"].(string))
+	ip.Dst().SetValue(tc["rxIp"].(string))
+
+	udp := f1.Packet().Add().Udp()
+	udp.SrcPort().SetValue(tc["txUdpPort"].(uint32))
+	udp.DstPort().SetValue(tc["rxUdpPort"].(uint32))
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
@@ -3,6 +3,9 @@
 package lldp
 
 import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,11 +15,18 @@ import (
 
 func TestLldpNeighbors(t *testing.T) {
 	testConst := map[string]interface{}{
-		"txMac":       "00:00:01:01:01:01",
-		"rxMac":       "00:00:01:01:01:02",
-		"holdTime":    uint32(120),
-		"advInterval": uint32(5),
-		"pduCount":    uint64(2),
+		"txMac":        "00:00:01:01:01:01",
+		"rxMac":        "00:00:01:01:01:02",
+		"txPortMac":    "00:00:01:01:02:01",
+		"rxPortMac":    "00:00:01:01:02:02",
+		"txSystemName": "lldpTxSystem",
+		"rxSystemName": "lldpRxSystem",
+		"oui":          "0012bb",
+		"ouiSubtype":   uint32(1),
+		"orgInfo":      "0a0b0c0d",
+		"holdTime":     uint32(120),
+		"advInterval":  uint32(5),
+		"pduCount":     uint64(2),
 	}
 
 	api := otg.NewOtgApi(t)
@@ -37,6 +47,44 @@ func TestLldpNeighbors(t *testing.T) {
 	)
 }
 
+// OTG LLDP configuration does not model management address and system
+// capabilities TLVs, hence these are sent in LLDPDUs crafted as raw flow and
+// checked as learnt by the receiving LLDP instance
+func TestLldpNeighborMgmtAddressAndCapabilities(t *testing.T) {
+	testConst := map[string]interface{}{
+		"txMac":       "00:00:01:01:01:01",
+		"rxMac":       "00:00:01:01:01:02",
+		"txPortMac":   "00:00:01:01:02:01",
+		"holdTime":    uint32(120),
+		"advInterval": uint32(5),
+		"mgmtIp":      "10.1.1.1",
+		"mgmtIfIndex": uint32(3),
+		/* supported system capabilities and whether each is enabled */
+		"capabilities": map[gosnappi.LldpCapabilityStateCapabilityNameEnum]bool{
+			gosnappi.LldpCapabilityStateCapabilityName.MAC_BRIDGE: false,
+			gosnappi.LldpCapabilityStateCapabilityName.ROUTER:     true,
+		},
+		"pktRate":  uint64(1),
+		"pktCount": uint32(10),
+		"pktSize":  uint32(128),
+	}
+
+	api := otg.NewOtgApi(t)
+	c := lldpNeighborMgmtAddressConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return lldpNeighborMgmtAddressNeighborsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForLldpNeighbors", Timeout: 30 * time.Second},
+	)
+
+	api.StopTransmit()
+}
+
 func lldpNeighborsConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
 	c := gosnappi.NewConfig()
 
@@ -56,12 +104,24 @@ func lldpNeighborsConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Co
 	lldpTx.Connection().SetPortName(ptx.Name())
 	lldpTx.ChassisId().MacAddressSubtype().
 		SetValue(tc["txMac"].(string))
+	lldpTx.PortId().SetMacAddressSubtype(tc["txPortMac"].(string))
+	lldpTx.SystemName().SetValue(tc["txSystemName"].(string))
+	lldpTx.OrgInfos().Add().
+		SetOui(tc["oui"].(string)).
+		SetSubtype(tc["ouiSubtype"].(uint32)).
+		Information().SetInfo(tc["orgInfo"].(string))
 
 	lldpRx.SetHoldTime(tc["holdTime"].(uint32))
 	lldpRx.SetAdvertisementInterval(tc["advInterval"].(uint32))
 	lldpRx.Connection().SetPortName(prx.Name())
 	lldpRx.ChassisId().MacAddressSubtype().
 		SetValue(tc["rxMac"].(string))
+	lldpRx.PortId().SetMacAddressSubtype(tc["rxPortMac"].(string))
+	lldpRx.SystemName().SetValue(tc["rxSystemName"].(string))
+	lldpRx.OrgInfos().Add().
+		SetOui(tc["oui"].(string)).
+		SetSubtype(tc["ouiSubtype"].(uint32)).
+		Information().SetInfo(tc["orgInfo"].(string))
 
 	api.Testing().Logf("Config:\n%v\n", c)
 	return c
@@ -83,10 +143,18 @@ func lldpNeighborsLldpMetricssOk(api *otg.OtgApi, tc map[string]interface{}) boo
 func lldpNeighborsLldpNeighborsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
 	count := 0
 	for _, n := range api.GetLldpNeighbors() {
-		for _, key := range []string{"txMac", "rxMac"} {
+		for _, key := range []string{"tx", "rx"} {
 			if n.HasChassisId() &&
 				n.ChassisIdType() == gosnappi.LldpNeighborsStateChassisIdType.MAC_ADDRESS &&
-				n.ChassisId() == tc[key].(string) {
+				n.ChassisId() == tc[key+"Mac"].(string) &&
+				n.HasPortId() &&
+				n.PortIdType() == gosnappi.LldpNeighborsStatePortIdType.MAC_ADDRESS &&
+				n.PortId() == tc[key+"PortMac"].(string) &&
+				n.HasSystemName() &&
+				n.SystemName() == tc[key+"SystemName"].(string) &&
+				n.HasTtl() &&
+				n.Ttl() == tc["holdTime"].(uint32) &&
+				lldpNeighborsCustomTlvOk(n, tc) {
 				count += 1
 			}
 		}
@@ -94,3 +162,138 @@ func lldpNeighborsLldpNeighborsOk(api *otg.OtgApi, tc map[string]interface{}) bo
 
 	return count == 2
 }
+
+func lldpNeighborsCustomTlvOk(n gosnappi.LldpNeighborsState, tc map[string]interface{}) bool {
+	for _, c := range n.CustomTlvs().Items() {
+		if strings.EqualFold(c.Oui(), tc["oui"].(string)) &&
+			c.OuiSubtype() == tc["ouiSubtype"].(uint32) &&
+			strings.EqualFold(c.Information(), tc["orgInfo"].(string)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lldpCapabilityBits maps system capabilities to bits of capabilities TLV as
+// per IEEE 802.1AB
+var lldpCapabilityBits = map[gosnappi.LldpCapabilityStateCapabilityNameEnum]uint16{
+	gosnappi.LldpCapabilityStateCapabilityName.OTHER:               1 << 0,
+	gosnappi.LldpCapabilityStateCapabilityName.REPEATER:            1 << 1,
+	gosnappi.LldpCapabilityStateCapabilityName.MAC_BRIDGE:          1 << 2,
+	gosnappi.LldpCapabilityStateCapabilityName.WLAN_ACCESS_POINT:   1 << 3,
+	gosnappi.LldpCapabilityStateCapabilityName.ROUTER:              1 << 4,
+	gosnappi.LldpCapabilityStateCapabilityName.TELEPHONE:           1 << 5,
+	gosnappi.LldpCapabilityStateCapabilityName.DOCSIS_CABLE_DEVICE: 1 << 6,
+	gosnappi.LldpCapabilityStateCapabilityName.STATION_ONLY:        1 << 7,
+	gosnappi.LldpCapabilityStateCapabilityName.C_VLAN:              1 << 8,
+	gosnappi.LldpCapabilityStateCapabilityName.S_VLAN:              1 << 9,
+	gosnappi.LldpCapabilityStateCapabilityName.TWO_PORT_MAC_RELAY:  1 << 10,
+}
+
+// lldpTlv returns LLDP TLV with 7-bit type and 9-bit length header
+func lldpTlv(tlvType uint16, value []byte) []byte {
+	b := binary.BigEndian.AppendUint16(nil, tlvType<<9|uint16(len(value)))
+	return append(b, value...)
+}
+
+// lldpNeighborMgmtAddressPdu returns LLDPDU carrying chassis ID, port ID, TTL,
+// system capabilities and management address TLVs, encoded as hex
+func lldpNeighborMgmtAddressPdu(api *otg.OtgApi, tc map[string]interface{}) string {
+	supported, enabled := uint16(0), uint16(0)
+	for name, en := range tc["capabilities"].(map[gosnappi.LldpCapabilityStateCapabilityNameEnum]bool) {
+		supported |= lldpCapabilityBits[name]
+		if en {
+			enabled |= lldpCapabilityBits[name]
+		}
+	}
+
+	/* address string length, IPv4 address family, address, ifIndex interface
+	   numbering subtype, interface number and empty OID */
+	mgmt := append([]byte{5, 1}, api.Ipv4AddrToBytes(tc["mgmtIp"].(string))...)
+	mgmt = append(mgmt, 2)
+	mgmt = binary.BigEndian.AppendUint32(mgmt, tc["mgmtIfIndex"].(uint32))
+	mgmt = append(mgmt, 0)
+
+	pdu := lldpTlv(1, append([]byte{4}, api.MacAddrToBytes(tc["txMac"].(string))...))
+	pdu = append(pdu, lldpTlv(2, append([]byte{3}, api.MacAddrToBytes(tc["txPortMac"].(string))...))...)
+	pdu = append(pdu, lldpTlv(3, binary.BigEndian.AppendUint16(nil, uint16(tc["holdTime"].(uint32))))...)
+	pdu = append(pdu, lldpTlv(7, binary.BigEndian.AppendUint32(nil, uint32(supported)<<16|uint32(enabled)))...)
+	pdu = append(pdu, lldpTlv(8, mgmt)...)
+	pdu = append(pdu, lldpTlv(0, nil)...)
+
+	return hex.EncodeToString(pdu)
+}
+
+func lldpNeighborMgmtAddressConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	lldpRx := c.Lldp().Add().SetName("lldpRx")
+
+	lldpRx.SetHoldTime(tc["holdTime"].(uint32))
+	lldpRx.SetAdvertisementInterval(tc["advInterval"].(uint32))
+	lldpRx.Connection().SetPortName(prx.Name())
+	lldpRx.ChassisId().MacAddressSubtype().
+		SetValue(tc["rxMac"].(string))
+
+	f1 := c.Flows().Add().SetName("f1")
+	f1.TxRx().Port().
+		SetTxName(ptx.Name()).
+		SetRxNames([]string{prx.Name()})
+	f1.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+	f1.Rate().SetPps(tc["pktRate"].(uint64))
+	f1.Size().SetFixed(tc["pktSize"].(uint32))
+
+	eth := f1.Packet().Add().Ethernet()
+	eth.Dst().SetValue("01:80:c2:00:00:0e")
+	eth.Src().SetValue(tc["txMac"].(string))
+	eth.EtherType().SetValue(0x88cc)
+
+	f1.Packet().Add().Custom().SetBytes(lldpNeighborMgmtAddressPdu(api, tc))
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func lldpNeighborMgmtAddressNeighborsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	t := api.Testing()
+	for _, n := range api.GetLldpNeighbors() {
+		if n.LldpName() != "lldpRx" || !n.HasChassisId() || n.ChassisId() != tc["txMac"].(string) {
+			continue
+		}
+
+		if !n.HasManagementAddress() || n.ManagementAddress() != tc["mgmtIp"].(string) {
+			t.Logf("WARNING: Management address of LLDP neighbor %s is not %s\n", n.ChassisId(), tc["mgmtIp"].(string))
+			return false
+		}
+		/* address family number 1 as per IANA */
+		if !n.HasManagementAddressType() ||
+			(!strings.EqualFold(n.ManagementAddressType(), "ipv4") && n.ManagementAddressType() != "1") {
+			t.Logf("WARNING: Management address type of LLDP neighbor %s is not ipv4\n", n.ChassisId())
+			return false
+		}
+
+		capabilities := tc["capabilities"].(map[gosnappi.LldpCapabilityStateCapabilityNameEnum]bool)
+		count := 0
+		for _, c := range n.Capabilities().Items() {
+			enabled, ok := capabilities[c.CapabilityName()]
+			if !ok || c.CapabilityEnabled() != enabled {
+				t.Logf("WARNING: Unexpected capability %s (enabled %v) of LLDP neighbor %s\n", c.CapabilityName(), c.CapabilityEnabled(), n.ChassisId())
+				return false
+			}
+			count += 1
+		}
+
+		return count == len(capabilities)
+	}
+
+	return false
+}
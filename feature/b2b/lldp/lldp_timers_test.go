@@ -0,0 +1,191 @@
+//go:build all || cpdp
+
+package lldp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestLldpHoldTimeExpiry(t *testing.T) {
+	testConst := map[string]interface{}{
+		"txMac": "00:00:01:01:01:01",
+		"rxMac": "00:00:01:01:01:02",
+		/* tx advertises less often than its hold time, so that rx ages out tx in between */
+		"txHoldTime":    uint32(10),
+		"txAdvInterval": uint32(30),
+		"rxHoldTime":    uint32(120),
+		"rxAdvInterval": uint32(5),
+		/* allows for neighbor being seen up to one poll interval after reception */
+		"expirySlack": 1 * time.Second,
+	}
+
+	api := otg.NewOtgApi(t)
+	c := lldpTimersConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	txMac := testConst["txMac"].(string)
+	rxMac := testConst["rxMac"].(string)
+
+	/* neighbor is taken to be learnt when it is first seen, which is no
+	   later than the time of reception by more than poll interval */
+	var learnt time.Time
+	api.WaitFor(
+		func() bool {
+			ok := lldpTimersNeighborOk(api, "lldpRx", txMac, testConst["txHoldTime"].(uint32))
+			if ok {
+				learnt = time.Now()
+			}
+			return ok
+		},
+		&otg.WaitForOpts{
+			FnName:  "WaitForLldpTxNeighbor",
+			Timeout: time.Duration(testConst["txAdvInterval"].(uint32)+10) * time.Second,
+		},
+	)
+
+	/* neighbor needs to be removed after TTL and before next advertisement is received */
+	api.WaitFor(
+		func() bool { return lldpTimersNeighborAbsent(api, "lldpRx", txMac) },
+		&otg.WaitForOpts{
+			FnName:  "WaitForLldpTxNeighborExpiry",
+			Timeout: time.Duration(testConst["txHoldTime"].(uint32)+5) * time.Second,
+		},
+	)
+	expired := time.Since(learnt)
+	t.Logf("LLDP neighbor %s expired %v after being learnt\n", txMac, expired)
+	minExpiry := time.Duration(testConst["txHoldTime"].(uint32))*time.Second - testConst["expirySlack"].(time.Duration)
+	if expired < minExpiry {
+		t.Fatalf("ERROR: LLDP neighbor %s expired %v after being learnt < %v\n", txMac, expired, minExpiry)
+	}
+
+	/* neighbor advertised within its hold time needs to stay */
+	if !lldpTimersNeighborOk(api, "lldpTx", rxMac, testConst["rxHoldTime"].(uint32)) {
+		t.Fatalf("ERROR: LLDP neighbor %s learnt by lldpTx expired before hold time\n", rxMac)
+	}
+}
+
+func TestLldpFrameRate(t *testing.T) {
+	testConst := map[string]interface{}{
+		"txMac":         "00:00:01:01:01:01",
+		"rxMac":         "00:00:01:01:01:02",
+		"txHoldTime":    uint32(120),
+		"txAdvInterval": uint32(5),
+		"rxHoldTime":    uint32(120),
+		"rxAdvInterval": uint32(5),
+		"pduCount":      uint64(4),
+	}
+
+	api := otg.NewOtgApi(t)
+	c := lldpTimersConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return lldpTimersFramesOk(api, nil, 1) },
+		&otg.WaitForOpts{FnName: "WaitForFirstLldpFrames", Timeout: 30 * time.Second},
+	)
+
+	base := map[string]gosnappi.LldpMetric{}
+	for _, m := range api.GetLldpMetrics() {
+		base[m.Name()] = m
+	}
+	start := time.Now()
+
+	/* pduCount frames can be sent no faster than (pduCount - 1) intervals and no slower than pduCount intervals */
+	pduCount := testConst["pduCount"].(uint64)
+	advInterval := time.Duration(testConst["txAdvInterval"].(uint32)) * time.Second
+	api.WaitFor(
+		func() bool { return lldpTimersFramesOk(api, base, pduCount) },
+		&otg.WaitForOpts{
+			FnName:  "WaitForLldpFrames",
+			Timeout: time.Duration(pduCount)*advInterval + 2*time.Second,
+		},
+	)
+
+	elapsed := time.Since(start)
+	minElapsed := time.Duration(pduCount-1) * advInterval
+	if elapsed < minElapsed {
+		t.Fatalf("ERROR: %d LLDP frames sent in %v < %v\n", pduCount, elapsed, minElapsed)
+	}
+}
+
+func lldpTimersConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	lldpTx := c.Lldp().Add().SetName("lldpTx")
+	lldpRx := c.Lldp().Add().SetName("lldpRx")
+
+	lldpTx.SetHoldTime(tc["txHoldTime"].(uint32))
+	lldpTx.SetAdvertisementInterval(tc["txAdvInterval"].(uint32))
+	lldpTx.Connection().SetPortName(ptx.Name())
+	lldpTx.ChassisId().MacAddressSubtype().
+		SetValue(tc["txMac"].(string))
+
+	lldpRx.SetHoldTime(tc["rxHoldTime"].(uint32))
+	lldpRx.SetAdvertisementInterval(tc["rxAdvInterval"].(uint32))
+	lldpRx.Connection().SetPortName(prx.Name())
+	lldpRx.ChassisId().MacAddressSubtype().
+		SetValue(tc["rxMac"].(string))
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func lldpTimersNeighborOk(api *otg.OtgApi, lldpName string, chassisId string, ttl uint32) bool {
+	for _, n := range api.GetLldpNeighbors() {
+		if n.LldpName() == lldpName &&
+			n.HasChassisId() && n.ChassisId() == chassisId &&
+			n.HasTtl() && n.Ttl() == ttl {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lldpTimersNeighborAbsent(api *otg.OtgApi, lldpName string, chassisId string) bool {
+	for _, n := range api.GetLldpNeighbors() {
+		if n.LldpName() == lldpName && n.HasChassisId() && n.ChassisId() == chassisId {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lldpTimersFramesOk(api *otg.OtgApi, base map[string]gosnappi.LldpMetric, pduCount uint64) bool {
+	t := api.Testing()
+	for _, m := range api.GetLldpMetrics() {
+		if m.FramesErrorRx() != 0 || m.FramesDiscard() != 0 {
+			t.Fatalf("ERROR: LLDP %s received %d error and %d discarded frames\n", m.Name(), m.FramesErrorRx(), m.FramesDiscard())
+		}
+
+		framesTx, framesRx := m.FramesTx(), m.FramesRx()
+		if b, ok := base[m.Name()]; ok {
+			framesTx -= b.FramesTx()
+			framesRx -= b.FramesRx()
+		}
+		if framesTx < pduCount || framesRx < pduCount {
+			return false
+		}
+	}
+
+	return true
+}
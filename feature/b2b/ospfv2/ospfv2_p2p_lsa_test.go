@@ -216,62 +216,54 @@ func ospfv2P2pLsaConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Con
 }
 
 func ospfv2P2pLsasOk(api *otg.OtgApi, tc map[string]interface{}) bool {
-	lsaCount := 0
-
-	var advRouterId string
-	var nwSummaryLsaId string
-	var routerLsaId string
-	var routerLsaLinkId string
-	var routerLsaLinkData string
-
-	for _, m := range api.GetOspfv2Lsas() {
-		if m.RouterName() == tc["txRouterName"] {
-			advRouterId = tc["rxIp"].(string)
-			nwSummaryLsaId = tc["rxAdvRouteV4"].(string)
-			routerLsaId = tc["rxIp"].(string)
-			routerLsaLinkId = tc["txIp"].(string)
-			routerLsaLinkData = tc["rxIp"].(string)
-		}
-		if m.RouterName() == tc["rxRouterName"] {
-			advRouterId = tc["txIp"].(string)
-			nwSummaryLsaId = tc["txAdvRouteV4"].(string)
-			routerLsaId = tc["txIp"].(string)
-			routerLsaLinkId = tc["rxIp"].(string)
-			routerLsaLinkData = tc["txIp"].(string)
-		}
-
-		// validate lsas
-		nwSummaryLsas := m.NetworkSummaryLsas().Items()
-		if len(nwSummaryLsas) == 1 &&
-			nwSummaryLsas[0].Metric() == 10 &&
-			nwSummaryLsas[0].Header().AdvertisingRouterId() == advRouterId &&
-			nwSummaryLsas[0].Header().LsaId() == nwSummaryLsaId {
-			lsaCount += 1
-		}
-
-		routerLsas := m.RouterLsas().Items()
-		if len(routerLsas) == 1 &&
-			routerLsas[0].Header().AdvertisingRouterId() == advRouterId &&
-			routerLsas[0].Header().LsaId() == routerLsaId &&
-			len(routerLsas[0].Links().Items()) == 2 {
-			hasPointToPoint := false
-			hasStub := false
-			for _, link := range routerLsas[0].Links().Items() {
-				if link.Type() == gosnappi.Ospfv2LinkType.STUB && (link.Metric() == 2) {
-					hasStub = true
-				}
-				if link.Type() == gosnappi.Ospfv2LinkType.POINT_TO_POINT && link.Id() == routerLsaLinkId &&
-					link.Data() == routerLsaLinkData && (link.Metric() == 2) {
-					hasPointToPoint = true
-				}
-			}
-			if hasPointToPoint && hasStub {
-				lsaCount += 1
+	t := api.Testing()
+	lsas := otg.FlattenOspfv2Lsas(api.GetOspfv2Lsas())
+
+	peers := map[string]string{"tx": "rx", "rx": "tx"}
+	for local, remote := range peers {
+		routerName := tc[local+"RouterName"].(string)
+		advRouterId := tc[remote+"Ip"].(string)
+
+		// validate lsas, with router lsa flattened into one entry per link
+		for _, e := range []struct {
+			match otg.OspfLsaMatch
+			count int
+		}{
+			{otg.OspfLsaMatch{RouterName: routerName, Type: otg.OspfLsaNetworkSummary}, 1},
+			{otg.OspfLsaMatch{
+				RouterName:          routerName,
+				Type:                otg.OspfLsaNetworkSummary,
+				LsaId:               tc[remote+"AdvRouteV4"].(string),
+				AdvertisingRouterId: advRouterId,
+				Metric:              10,
+			}, 1},
+			{otg.OspfLsaMatch{RouterName: routerName, Type: otg.OspfLsaRouter}, 2},
+			{otg.OspfLsaMatch{
+				RouterName:          routerName,
+				Type:                otg.OspfLsaRouter,
+				LsaId:               advRouterId,
+				AdvertisingRouterId: advRouterId,
+				LinkType:            string(gosnappi.Ospfv2LinkType.POINT_TO_POINT),
+				LinkId:              tc[local+"Ip"].(string),
+				LinkData:            tc[remote+"Ip"].(string),
+				Metric:              2,
+			}, 1},
+			{otg.OspfLsaMatch{
+				RouterName:          routerName,
+				Type:                otg.OspfLsaRouter,
+				LsaId:               advRouterId,
+				AdvertisingRouterId: advRouterId,
+				LinkType:            string(gosnappi.Ospfv2LinkType.STUB),
+				Metric:              2,
+			}, 1},
+		} {
+			if !e.match.Has(t, lsas, e.count) {
+				return false
 			}
 		}
 	}
 
-	return lsaCount == 4
+	return true
 }
 
 func ospfv2P2pLsaMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
@@ -245,60 +245,50 @@ func ospfv3P2pLsaMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
 }
 
 func ospfv3P2pLsasOk(api *otg.OtgApi, tc map[string]interface{}) bool {
-	lsaCount := 0
-
-	var advRouterId string
-	var addrPrefix string
-	var metric uint32
-	var routerLsaNbrRouterId string
-	var routerLsaLinkMetric uint32
-	routerLsaLinkType := gosnappi.Ospfv3LinkType.POINT_TO_POINT
-
-	for _, m := range api.GetOspfv3Lsas() {
-		if m.RouterName() == tc["txRouterName"] {
-			advRouterId = tc["rxRouterId"].(string)
-			addrPrefix = tc["rxAddrPrefix"].(string)
-			routerLsaNbrRouterId = tc["txRouterId"].(string)
-			metric = tc["rxMetric"].(uint32)
-			routerLsaLinkMetric = tc["rxLinkMetric"].(uint32)
-		}
-		if m.RouterName() == tc["rxRouterName"] {
-			advRouterId = tc["txRouterId"].(string)
-			addrPrefix = tc["txAddrPrefix"].(string)
-			routerLsaNbrRouterId = tc["rxRouterId"].(string)
-			metric = tc["txMetric"].(uint32)
-			routerLsaLinkMetric = tc["txLinkMetric"].(uint32)
-		}
-
-		// validate lsas
-		interAreaPrefixLsas := m.InterAreaPrefixLsas().Items()
-		if len(interAreaPrefixLsas) == 1 &&
-			interAreaPrefixLsas[0].AddressPrefix() == addrPrefix &&
-			interAreaPrefixLsas[0].Header().AdvertisingRouterId() == advRouterId &&
-			interAreaPrefixLsas[0].Metric() == metric {
-			lsaCount += 1
-		}
-
-		linkLsas := m.LinkLsas().Items()
-		if len(linkLsas) == 1 &&
-			linkLsas[0].Header().AdvertisingRouterId() == advRouterId {
-			lsaCount += 1
-		}
-
-		routerLsas := m.RouterLsas().Items()
-		if len(routerLsas) == 1 &&
-			routerLsas[0].Header().AdvertisingRouterId() == advRouterId &&
-			routerLsas[0].NeighborRouterId() == routerLsaNbrRouterId &&
-			len(routerLsas[0].Links().Items()) == 1 {
-			links := routerLsas[0].Links().Items()
-			if links[0].Type() == routerLsaLinkType &&
-				links[0].Metric() == routerLsaLinkMetric {
-				lsaCount += 1
+	t := api.Testing()
+	lsas := otg.FlattenOspfv3Lsas(api.GetOspfv3Lsas())
+
+	peers := map[string]string{"tx": "rx", "rx": "tx"}
+	for local, remote := range peers {
+		routerName := tc[local+"RouterName"].(string)
+		advRouterId := tc[remote+"RouterId"].(string)
+
+		// validate lsas, with router lsa flattened into one entry per link
+		for _, e := range []struct {
+			match otg.OspfLsaMatch
+			count int
+		}{
+			{otg.OspfLsaMatch{RouterName: routerName, Type: otg.OspfLsaInterAreaPrefix}, 1},
+			{otg.OspfLsaMatch{
+				RouterName:          routerName,
+				Type:                otg.OspfLsaInterAreaPrefix,
+				AdvertisingRouterId: advRouterId,
+				Prefix:              tc[remote+"AddrPrefix"].(string) + "/64",
+				Metric:              tc[remote+"Metric"].(uint32),
+			}, 1},
+			{otg.OspfLsaMatch{RouterName: routerName, Type: otg.OspfLsaLink}, 1},
+			{otg.OspfLsaMatch{
+				RouterName:          routerName,
+				Type:                otg.OspfLsaLink,
+				AdvertisingRouterId: advRouterId,
+			}, 1},
+			{otg.OspfLsaMatch{RouterName: routerName, Type: otg.OspfLsaRouter}, 1},
+			{otg.OspfLsaMatch{
+				RouterName:          routerName,
+				Type:                otg.OspfLsaRouter,
+				AdvertisingRouterId: advRouterId,
+				LinkType:            string(gosnappi.Ospfv3LinkType.POINT_TO_POINT),
+				LinkId:              tc[local+"RouterId"].(string),
+				Metric:              tc[remote+"LinkMetric"].(uint32),
+			}, 1},
+		} {
+			if !e.match.Has(t, lsas, e.count) {
+				return false
 			}
 		}
 	}
 
-	return lsaCount == 6
+	return true
 }
 
 func ospfv3P2pLsaFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
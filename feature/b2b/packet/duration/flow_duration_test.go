@@ -0,0 +1,229 @@
+//go:build all || dp
+
+package duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func flowDurationTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktRate":   uint64(100),
+		"pktSize":   uint32(128),
+		"txMac":     "00:00:01:01:01:01",
+		"rxMac":     "00:00:01:01:01:02",
+		"txIp":      "1.1.1.1",
+		"rxIp":      "1.1.1.2",
+		"txUdpPort": uint32(5000),
+		"rxUdpPort": uint32(6000),
+		/* allowed deviation (fraction) of packet counts and timing */
+		"tolerance": 0.05,
+	}
+}
+
+func TestFlowDurationContinuous(t *testing.T) {
+	testConst := flowDurationTestConst()
+	d := gosnappi.NewFlowDuration()
+	d.Continuous()
+	testConst["duration"] = d
+	/* frames to be transmitted before flow is stopped explicitly */
+	testConst["minPktCount"] = uint64(300)
+
+	api := otg.NewOtgApi(t)
+	c := flowDurationConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartCapture()
+	start := time.Now()
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool {
+			m := api.GetFlowMetrics()[0]
+			return m.Transmit() == gosnappi.FlowMetricTransmit.STARTED &&
+				m.FramesTx() >= testConst["minPktCount"].(uint64)
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitStarted"},
+	)
+
+	api.StopTransmit()
+	elapsed := time.Since(start)
+
+	var txCount uint64
+	api.WaitFor(
+		func() bool {
+			m := api.GetFlowMetrics()[0]
+			txCount = m.FramesTx()
+			return m.Transmit() == gosnappi.FlowMetricTransmit.STOPPED && m.FramesRx() == txCount
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitStopped"},
+	)
+
+	/* no frames are expected to be sent once flow is stopped */
+	time.Sleep(2 * time.Second)
+	if m := api.GetFlowMetrics()[0]; m.FramesTx() != txCount {
+		t.Fatalf("ERROR: Frames sent after stop: %d != %d\n", m.FramesTx(), txCount)
+	}
+
+	/* elapsed time includes latency of control requests, hence is upper bound */
+	ft := api.ExpectedFlowTiming(c.Flows().Items()[0])
+	if maxCount := uint64(math.Ceil(ft.Pps*elapsed.Seconds()*(1+testConst["tolerance"].(float64)))) + 1; txCount > maxCount {
+		t.Fatalf("ERROR: Frames sent in %v: %d > %d\n", elapsed, txCount, maxCount)
+	}
+
+	api.StopCapture()
+
+	flowDurationCaptureOk(api, c, testConst, txCount)
+}
+
+func TestFlowDurationFixedSeconds(t *testing.T) {
+	testConst := flowDurationTestConst()
+	d := gosnappi.NewFlowDuration()
+	d.FixedSeconds().SetSeconds(5)
+	testConst["duration"] = d
+
+	flowDurationTest(t, testConst)
+}
+
+func TestFlowDurationBurst(t *testing.T) {
+	testConst := flowDurationTestConst()
+	d := gosnappi.NewFlowDuration()
+	d.Burst().
+		SetBursts(5).
+		SetPackets(20).
+		InterBurstGap().SetMicroseconds(500000)
+	testConst["duration"] = d
+
+	flowDurationTest(t, testConst)
+}
+
+func TestFlowDurationBurstGapBytes(t *testing.T) {
+	testConst := flowDurationTestConst()
+	d := gosnappi.NewFlowDuration()
+	/* 62500000 bytes amount to 500ms at default speed of 1 Gbps */
+	d.Burst().
+		SetBursts(4).
+		SetPackets(50).
+		InterBurstGap().SetBytes(62500000)
+	testConst["duration"] = d
+
+	flowDurationTest(t, testConst)
+}
+
+func TestFlowDurationBurstGap(t *testing.T) {
+	testConst := flowDurationTestConst()
+	/* packets in each burst are sent at line rate, so that gap of 1000 bytes
+	   rather than rate determines spacing of packets */
+	rate := gosnappi.NewFlowRate()
+	rate.SetPercentage(100)
+	testConst["rate"] = rate
+	d := gosnappi.NewFlowDuration()
+	d.Burst().
+		SetBursts(5).
+		SetPackets(20).
+		SetGap(1000).
+		InterBurstGap().SetMicroseconds(500000)
+	testConst["duration"] = d
+
+	flowDurationTest(t, testConst)
+}
+
+func flowDurationTest(t *testing.T, testConst map[string]interface{}) {
+	api := otg.NewOtgApi(t)
+	c := flowDurationConfig(api, testConst)
+	ft := api.ExpectedFlowTiming(c.Flows().Items()[0])
+	t.Logf("Expected timing: %+v\n", ft)
+
+	api.SetConfig(c)
+
+	api.StartCapture()
+	api.StartTransmit()
+
+	var txCount uint64
+	api.WaitFor(
+		func() bool {
+			m := api.GetFlowMetrics()[0]
+			txCount = m.FramesTx()
+			return m.Transmit() == gosnappi.FlowMetricTransmit.STOPPED && m.FramesRx() == txCount
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics", Timeout: ft.Duration + 30*time.Second},
+	)
+
+	/* only frames sent for fixed seconds are subject to tolerance */
+	tolerance := 0.0
+	if c.Flows().Items()[0].Duration().Choice() == gosnappi.FlowDurationChoice.FIXED_SECONDS {
+		tolerance = testConst["tolerance"].(float64)
+	}
+	if math.Abs(float64(txCount)-float64(ft.Frames)) > tolerance*float64(ft.Frames) {
+		t.Fatalf("ERROR: expCount %d != actCount %d\n", ft.Frames, txCount)
+	}
+
+	api.StopCapture()
+
+	flowDurationCaptureOk(api, c, testConst, txCount)
+}
+
+func flowDurationConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+	p1 := c.Ports().Add().SetName("p1").SetLocation(api.TestConfig().OtgPorts[0])
+	p2 := c.Ports().Add().SetName("p2").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{p1.Name(), p2.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	if api.TestConfig().OtgCaptureCheck {
+		c.Captures().Add().
+			SetName("ca").
+			SetPortNames([]string{p2.Name()}).
+			SetFormat(gosnappi.CaptureFormat.PCAP)
+	}
+
+	f1 := c.Flows().Add().SetName("f1")
+	f1.TxRx().Port().
+		SetTxName(p1.Name()).
+		SetRxNames([]string{p2.Name()})
+	f1.SetDuration(tc["duration"].(gosnappi.FlowDuration))
+	if r, ok := tc["rate"]; ok {
+		f1.SetRate(r.(gosnappi.FlowRate))
+	} else {
+		f1.Rate().SetPps(tc["pktRate"].(uint64))
+	}
+	f1.Size().SetFixed(tc["pktSize"].(uint32))
+	f1.Metrics().SetEnable(true)
+
+	eth := f1.Packet().Add().Ethernet()
+	eth.Src().SetValue(tc["txMac"].(string))
+	eth.Dst().SetValue(tc["rxMac"].(string))
+
+	ip := f1.Packet().Add().Ipv4()
+	ip.Src().SetValue(tc["txIp"].(string))
+	ip.Dst().SetValue(tc["rxIp"].(string))
+
+	udp := f1.Packet().Add().Udp()
+	udp.SrcPort().SetValue(tc["txUdpPort"].(uint32))
+	udp.DstPort().SetValue(tc["rxUdpPort"].(uint32))
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func flowDurationCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]interface{}, txCount uint64) {
+	if !api.TestConfig().OtgCaptureCheck {
+		return
+	}
+	cPackets := api.GetCapture(c.Ports().Items()[1].Name())
+	t := api.Testing()
+
+	actCount := api.ValidatePacketTiming(cPackets, c.Flows().Items()[0], tc["txMac"].(string), tc["tolerance"].(float64))
+	if uint64(actCount) != txCount {
+		t.Fatalf("ERROR: expCount %d != actCount %d\n", txCount, actCount)
+	}
+}
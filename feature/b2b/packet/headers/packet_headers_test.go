@@ -0,0 +1,616 @@
+//go:build all || dp
+
+package headers
+
+import (
+	"testing"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+)
+
+/* Each entry describes the header stack of a flow, where every field is
+   expressed as OTG pattern (value, values, increment, decrement, random or
+   checksum). The flow is sent from first to second OTG port, and each
+   captured packet is validated against the patterns, along with length and
+   next header fields auto filled in by OTG, and known checksums recomputed
+   from packet data. */
+
+const (
+	ethernetHeader = `
+choice: ethernet
+ethernet:
+  src: {choice: value, value: "00:00:01:01:01:01"}
+  dst: {choice: value, value: "00:00:01:01:01:02"}
+`
+	ipv4Header = `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "1.1.1.1"}
+  dst: {choice: value, value: "1.1.1.2"}
+`
+	ipv6Header = `
+choice: ipv6
+ipv6:
+  src: {choice: value, value: "2000::1"}
+  dst: {choice: value, value: "2000::2"}
+`
+	udpHeader = `
+choice: udp
+udp:
+  src_port: {choice: value, value: 5000}
+  dst_port: {choice: value, value: 6000}
+`
+)
+
+func packetHeadersSpecs() []otg.PacketHeaderSpec {
+	return []otg.PacketHeaderSpec{
+		{
+			Name: "UdpChecksumCustom",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: udp
+udp:
+  src_port: {choice: value, value: 5000}
+  dst_port: {choice: value, value: 6000}
+  checksum: {choice: custom, custom: 4660}
+`},
+		},
+		{
+			Name: "UdpChecksumBad",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: udp
+udp:
+  src_port: {choice: value, value: 5000}
+  dst_port: {choice: value, value: 6000}
+  checksum: {choice: generated, generated: bad}
+`},
+		},
+		{
+			Name: "Ipv4ChecksumBad",
+			Headers: []string{ethernetHeader, `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "1.1.1.1"}
+  dst: {choice: value, value: "1.1.1.2"}
+  header_checksum: {choice: generated, generated: bad}
+`, udpHeader},
+		},
+		{
+			Name: "Ipv4ChecksumCustom",
+			Headers: []string{ethernetHeader, `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "1.1.1.1"}
+  dst: {choice: value, value: "1.1.1.2"}
+  header_checksum: {choice: custom, custom: 65535}
+`, udpHeader},
+		},
+		{
+			Name: "TcpFlagsWindow",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: tcp
+tcp:
+  src_port: {choice: value, value: 5000}
+  dst_port: {choice: value, value: 6000}
+  seq_num: {choice: increment, increment: {start: 1000, step: 100, count: 50}}
+  ack_num: {choice: value, value: 2000}
+  data_offset: {choice: value, value: 5}
+  ctl_syn: {choice: value, value: 1}
+  ctl_ack: {choice: value, value: 1}
+  ctl_fin: {choice: value, value: 0}
+  window: {choice: values, values: [1024, 2048, 4096]}
+`},
+		},
+		{
+			Name: "TcpChecksumBad",
+			Headers: []string{ethernetHeader, ipv6Header, `
+choice: tcp
+tcp:
+  src_port: {choice: value, value: 5000}
+  dst_port: {choice: value, value: 6000}
+  checksum: {choice: generated, generated: bad}
+`},
+		},
+		{
+			Name: "TcpChecksumCustom",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: tcp
+tcp:
+  src_port: {choice: value, value: 5000}
+  dst_port: {choice: value, value: 6000}
+  checksum: {choice: custom, custom: 4660}
+`},
+		},
+		{
+			Name: "Ipv4PriorityTtl",
+			Headers: []string{ethernetHeader, `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "1.1.1.1"}
+  dst: {choice: value, value: "1.1.1.2"}
+  priority:
+    choice: dscp
+    dscp:
+      phb: {choice: values, values: [0, 10, 46]}
+      ecn: {choice: value, value: 1}
+  identification: {choice: decrement, decrement: {start: 100, step: 1, count: 100}}
+  dont_fragment: {choice: value, value: 1}
+  time_to_live: {choice: value, value: 32}
+`, udpHeader},
+		},
+		{
+			Name: "Ipv4AddrIncrRandom",
+			Headers: []string{ethernetHeader, `
+choice: ipv4
+ipv4:
+  src: {choice: increment, increment: {start: "1.1.1.1", step: "0.0.0.1", count: 5}}
+  dst: {choice: random, random: {min: "1.1.2.1", max: "1.1.2.254", seed: 1, count: 100}}
+`, udpHeader},
+		},
+		{
+			Name: "EthernetSrcIncr",
+			Headers: []string{`
+choice: ethernet
+ethernet:
+  src: {choice: increment, increment: {start: "00:00:01:01:01:01", step: "00:00:00:00:01:00", count: 4}}
+  dst: {choice: value, value: "00:00:01:01:01:02"}
+`, ipv4Header, udpHeader},
+		},
+		{
+			Name: "Vlan",
+			Headers: []string{ethernetHeader, `
+choice: vlan
+vlan:
+  priority: {choice: values, values: [0, 3, 7]}
+  cfi: {choice: value, value: 0}
+  id: {choice: increment, increment: {start: 100, step: 1, count: 10}}
+`, ipv4Header, udpHeader},
+		},
+		{
+			Name: "VlanStacked",
+			Headers: []string{ethernetHeader, `
+choice: vlan
+vlan:
+  priority: {choice: value, value: 5}
+  id: {choice: value, value: 100}
+`, `
+choice: vlan
+vlan:
+  priority: {choice: increment, increment: {start: 0, step: 1, count: 8}}
+  cfi: {choice: value, value: 1}
+  id: {choice: decrement, decrement: {start: 200, step: 2, count: 5}}
+`, ipv4Header, udpHeader},
+		},
+		{
+			Name: "Qinq",
+			Headers: []string{`
+choice: ethernet
+ethernet:
+  src: {choice: value, value: "00:00:01:01:01:01"}
+  dst: {choice: value, value: "00:00:01:01:01:02"}
+  ether_type: {choice: value, value: 34984}
+`, `
+choice: vlan
+vlan:
+  priority: {choice: value, value: 3}
+  id: {choice: value, value: 10}
+  tpid: {choice: value, value: 33024}
+`, `
+choice: vlan
+vlan:
+  id: {choice: values, values: [100, 200, 300]}
+`, ipv6Header, udpHeader},
+		},
+		{
+			Name: "Mpls",
+			Headers: []string{ethernetHeader, `
+choice: mpls
+mpls:
+  label: {choice: value, value: 1000}
+  traffic_class: {choice: value, value: 5}
+  time_to_live: {choice: value, value: 64}
+`, ipv4Header, udpHeader},
+		},
+		{
+			Name: "MplsStack",
+			Headers: []string{ethernetHeader, `
+choice: mpls
+mpls:
+  label: {choice: increment, increment: {start: 16000, step: 1, count: 10}}
+  traffic_class: {choice: values, values: [0, 7]}
+  time_to_live: {choice: value, value: 255}
+`, `
+choice: mpls
+mpls:
+  label: {choice: value, value: 24001}
+  traffic_class: {choice: value, value: 1}
+  time_to_live: {choice: decrement, decrement: {start: 64, step: 1, count: 4}}
+`, `
+choice: mpls
+mpls:
+  label: {choice: values, values: [100, 200, 300]}
+  traffic_class: {choice: value, value: 3}
+  time_to_live: {choice: value, value: 1}
+`, ipv4Header, udpHeader},
+		},
+		{
+			Name: "VlanMpls",
+			Headers: []string{ethernetHeader, `
+choice: vlan
+vlan:
+  id: {choice: value, value: 100}
+`, `
+choice: mpls
+mpls:
+  label: {choice: value, value: 2000}
+  bottom_of_stack: {choice: value, value: 1}
+`, ipv6Header, udpHeader},
+		},
+		{
+			Name: "Ipv4InIpv4",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: ipv4
+ipv4:
+  src: {choice: increment, increment: {start: "10.1.1.1", step: "0.0.0.1", count: 10}}
+  dst: {choice: value, value: "10.1.2.1"}
+  time_to_live: {choice: value, value: 16}
+`, udpHeader},
+		},
+		{
+			Name: "Ipv6InIpv4",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: ipv6
+ipv6:
+  src: {choice: value, value: "3000::1"}
+  dst: {choice: values, values: ["3000::2", "3000::3", "3000::4"]}
+  flow_label: {choice: value, value: 100}
+`, udpHeader},
+		},
+		{
+			Name: "GreIpv4",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: gre
+gre:
+  version: {choice: value, value: 0}
+`, `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "10.1.1.1"}
+  dst: {choice: increment, increment: {start: "10.1.2.1", step: "0.0.0.1", count: 5}}
+`, udpHeader},
+		},
+		{
+			Name: "GreChecksumIpv6",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: gre
+gre:
+  checksum_present: {choice: value, value: 1}
+`, `
+choice: ipv6
+ipv6:
+  src: {choice: value, value: "3000::1"}
+  dst: {choice: value, value: "3000::2"}
+`, udpHeader},
+		},
+		{
+			/* OTG GRE header does not model key, hence key present bit is set
+			   in reserved0 and key is appended as custom bytes; protocol is set
+			   explicitly since it cannot be derived from custom header */
+			Name: "GreKey",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: gre
+gre:
+  reserved0: {choice: value, value: 1024}
+  protocol: {choice: value, value: 2048}
+`, `
+choice: custom
+custom:
+  bytes: "0000abcd"
+`, `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "10.1.1.1"}
+  dst: {choice: value, value: "10.1.2.1"}
+`, udpHeader},
+		},
+		{
+			Name: "GreChecksumKey",
+			Headers: []string{ethernetHeader, ipv6Header, `
+choice: gre
+gre:
+  checksum_present: {choice: value, value: 1}
+  reserved0: {choice: value, value: 1024}
+  protocol: {choice: value, value: 2048}
+`, `
+choice: custom
+custom:
+  bytes: "12345678"
+`, `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "10.1.1.1"}
+  dst: {choice: value, value: "10.1.2.1"}
+`, udpHeader},
+		},
+		{
+			Name: "GreChecksumBad",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: gre
+gre:
+  checksum_present: {choice: value, value: 1}
+  checksum: {choice: generated, generated: bad}
+`, `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "10.1.1.1"}
+  dst: {choice: value, value: "10.1.2.1"}
+`, udpHeader},
+		},
+		{
+			Name: "Gtpv1Teid",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: udp
+udp:
+  src_port: {choice: value, value: 2152}
+  dst_port: {choice: value, value: 2152}
+`, `
+choice: gtpv1
+gtpv1:
+  message_type: {choice: value, value: 255}
+  teid: {choice: increment, increment: {start: 4096, step: 16, count: 20}}
+`, `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "10.1.1.1"}
+  dst: {choice: value, value: "10.1.2.1"}
+`, udpHeader},
+		},
+		{
+			Name: "Gtpv1Sequence",
+			Headers: []string{ethernetHeader, ipv6Header, `
+choice: udp
+udp:
+  src_port: {choice: value, value: 2152}
+  dst_port: {choice: value, value: 2152}
+`, `
+choice: gtpv1
+gtpv1:
+  s_flag: {choice: value, value: 1}
+  message_type: {choice: value, value: 255}
+  teid: {choice: values, values: [1, 4294967295, 65536]}
+  squence_number: {choice: increment, increment: {start: 1, step: 1, count: 100}}
+`, `
+choice: ipv6
+ipv6:
+  src: {choice: value, value: "3000::1"}
+  dst: {choice: value, value: "3000::2"}
+`, udpHeader},
+		},
+		{
+			Name: "IcmpEchoRequest",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: icmp
+icmp:
+  choice: echo
+  echo:
+    type: {choice: value, value: 8}
+    code: {choice: value, value: 0}
+    identifier: {choice: values, values: [1, 2, 3]}
+    sequence_number: {choice: increment, increment: {start: 1, step: 1, count: 100}}
+`},
+		},
+		{
+			Name: "IcmpEchoReply",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: icmp
+icmp:
+  choice: echo
+  echo:
+    type: {choice: value, value: 0}
+    code: {choice: value, value: 0}
+    identifier: {choice: value, value: 4660}
+    sequence_number: {choice: decrement, decrement: {start: 100, step: 1, count: 100}}
+`},
+		},
+		{
+			Name: "IcmpChecksumBad",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: icmp
+icmp:
+  choice: echo
+  echo:
+    type: {choice: value, value: 8}
+    checksum: {choice: generated, generated: bad}
+`},
+		},
+		{
+			Name: "IcmpChecksumCustom",
+			Headers: []string{ethernetHeader, ipv4Header, `
+choice: icmp
+icmp:
+  choice: echo
+  echo:
+    type: {choice: value, value: 8}
+    checksum: {choice: custom, custom: 4660}
+`},
+		},
+		{
+			Name: "Icmpv6EchoRequest",
+			Headers: []string{ethernetHeader, ipv6Header, `
+choice: icmpv6
+icmpv6:
+  choice: echo
+  echo:
+    type: {choice: value, value: 128}
+    code: {choice: value, value: 0}
+    identifier: {choice: value, value: 1}
+    sequence_number: {choice: increment, increment: {start: 1, step: 1, count: 100}}
+`},
+		},
+		{
+			/* OTG ICMPv6 header only models echo, hence neighbor solicitation
+			   is built with reserved field as echo identifier / sequence number,
+			   followed by target address and source link-layer address option
+			   as custom bytes */
+			Name: "Icmpv6NeighborSolicitation",
+			Headers: []string{`
+choice: ethernet
+ethernet:
+  src: {choice: value, value: "00:00:01:01:01:01"}
+  dst: {choice: value, value: "33:33:ff:00:00:02"}
+`, `
+choice: ipv6
+ipv6:
+  src: {choice: value, value: "2000::1"}
+  dst: {choice: value, value: "ff02::1:ff00:2"}
+  hop_limit: {choice: value, value: 255}
+`, `
+choice: icmpv6
+icmpv6:
+  choice: echo
+  echo:
+    type: {choice: value, value: 135}
+    code: {choice: value, value: 0}
+    identifier: {choice: value, value: 0}
+    sequence_number: {choice: value, value: 0}
+`, `
+choice: custom
+custom:
+  bytes: "200000000000000000000000000000020101000001010101"
+`},
+		},
+		{
+			/* MLDv1 report is built similarly, where maximum response delay
+			   and reserved fields are echo identifier / sequence number */
+			Name: "Icmpv6MldReport",
+			Headers: []string{`
+choice: ethernet
+ethernet:
+  src: {choice: value, value: "00:00:01:01:01:01"}
+  dst: {choice: value, value: "33:33:00:00:01:01"}
+`, `
+choice: ipv6
+ipv6:
+  src: {choice: value, value: "fe80::1"}
+  dst: {choice: value, value: "ff0e::101"}
+  hop_limit: {choice: value, value: 1}
+`, `
+choice: icmpv6
+icmpv6:
+  choice: echo
+  echo:
+    type: {choice: value, value: 131}
+    code: {choice: value, value: 0}
+    identifier: {choice: value, value: 0}
+    sequence_number: {choice: value, value: 0}
+`, `
+choice: custom
+custom:
+  bytes: "ff0e0000000000000000000000000101"
+`},
+		},
+		{
+			Name: "ArpRequest",
+			Headers: []string{`
+choice: ethernet
+ethernet:
+  src: {choice: value, value: "00:00:01:01:01:01"}
+  dst: {choice: value, value: "ff:ff:ff:ff:ff:ff"}
+`, `
+choice: arp
+arp:
+  hardware_type: {choice: value, value: 1}
+  protocol_type: {choice: value, value: 2048}
+  hardware_length: {choice: value, value: 6}
+  protocol_length: {choice: value, value: 4}
+  operation: {choice: value, value: 1}
+  sender_hardware_addr: {choice: value, value: "00:00:01:01:01:01"}
+  sender_protocol_addr: {choice: value, value: "1.1.1.1"}
+  target_hardware_addr: {choice: value, value: "00:00:00:00:00:00"}
+  target_protocol_addr: {choice: increment, increment: {start: "1.1.1.2", step: "0.0.0.1", count: 10}}
+`},
+		},
+		{
+			Name: "ArpReply",
+			Headers: []string{ethernetHeader, `
+choice: arp
+arp:
+  operation: {choice: value, value: 2}
+  sender_hardware_addr: {choice: increment, increment: {start: "00:00:01:01:02:01", step: "00:00:00:00:00:01", count: 10}}
+  sender_protocol_addr: {choice: increment, increment: {start: "1.1.1.2", step: "0.0.0.1", count: 10}}
+  target_hardware_addr: {choice: value, value: "00:00:01:01:01:01"}
+  target_protocol_addr: {choice: value, value: "1.1.1.1"}
+`},
+		},
+		{
+			Name: "Igmpv1Query",
+			Headers: []string{`
+choice: ethernet
+ethernet:
+  src: {choice: value, value: "00:00:01:01:01:01"}
+  dst: {choice: value, value: "01:00:5e:00:00:01"}
+`, `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "1.1.1.1"}
+  dst: {choice: value, value: "224.0.0.1"}
+  time_to_live: {choice: value, value: 1}
+`, `
+choice: igmpv1
+igmpv1:
+  version: {choice: value, value: 1}
+  type: {choice: value, value: 1}
+  group_address: {choice: value, value: "0.0.0.0"}
+`},
+		},
+		{
+			Name: "Igmpv1Report",
+			Headers: []string{`
+choice: ethernet
+ethernet:
+  src: {choice: value, value: "00:00:01:01:01:01"}
+  dst: {choice: value, value: "01:00:5e:01:01:01"}
+`, `
+choice: ipv4
+ipv4:
+  src: {choice: value, value: "1.1.1.1"}
+  dst: {choice: value, value: "225.1.1.1"}
+  time_to_live: {choice: value, value: 1}
+`, `
+choice: igmpv1
+igmpv1:
+  version: {choice: value, value: 1}
+  type: {choice: value, value: 2}
+  group_address: {choice: increment, increment: {start: "225.1.1.1", step: "0.0.0.1", count: 10}}
+`},
+		},
+		{
+			Name: "Ipv6TrafficClassFlowLabel",
+			Headers: []string{ethernetHeader, `
+choice: ipv6
+ipv6:
+  src: {choice: increment, increment: {start: "2000::1", step: "::1", count: 10}}
+  dst: {choice: value, value: "2000::2"}
+  traffic_class: {choice: values, values: [0, 40, 184]}
+  flow_label: {choice: increment, increment: {start: 1, step: 16, count: 20}}
+  hop_limit: {choice: value, value: 16}
+`, `
+choice: tcp
+tcp:
+  src_port: {choice: value, value: 5000}
+  dst_port: {choice: value, value: 6000}
+`},
+		},
+	}
+}
+
+func TestPacketHeaders(t *testing.T) {
+	for _, spec := range packetHeadersSpecs() {
+		spec.PktRate = 50
+		spec.PktCount = 100
+		spec.PktSize = 128
+
+		t.Run(spec.Name, func(t *testing.T) {
+			otg.NewOtgApi(t).RunPacketHeaderTest(spec)
+		})
+	}
+}
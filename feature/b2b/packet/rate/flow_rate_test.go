@@ -0,0 +1,177 @@
+//go:build all || dp
+
+package rate
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func flowRateTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktSize":   uint32(512),
+		"txMac":     "00:00:01:01:01:01",
+		"rxMac":     "00:00:01:01:01:02",
+		"txIp":      "1.1.1.1",
+		"rxIp":      "1.1.1.2",
+		"txUdpPort": uint32(5000),
+		"rxUdpPort": uint32(6000),
+		/* transmit is given time to reach steady rate before measuring */
+		"warmup": 2 * time.Second,
+		"window": 5 * time.Second,
+		/* allowed deviation (fraction) of measured rates */
+		"tolerance": 0.02,
+	}
+}
+
+func TestFlowRateUnits(t *testing.T) {
+	for _, r := range []struct {
+		name string
+		set  func(gosnappi.FlowRate)
+	}{
+		{"Pps", func(r gosnappi.FlowRate) { r.SetPps(10000) }},
+		{"Bps", func(r gosnappi.FlowRate) { r.SetBps(100000000) }},
+		{"Kbps", func(r gosnappi.FlowRate) { r.SetKbps(200000) }},
+		{"Mbps", func(r gosnappi.FlowRate) { r.SetMbps(300) }},
+		{"Gbps", func(r gosnappi.FlowRate) { r.SetGbps(1) }},
+		{"Percentage10", func(r gosnappi.FlowRate) { r.SetPercentage(10) }},
+		{"Percentage50", func(r gosnappi.FlowRate) { r.SetPercentage(50) }},
+	} {
+		t.Run(r.name, func(t *testing.T) {
+			testConst := flowRateTestConst()
+			rate := gosnappi.NewFlowRate()
+			r.set(rate)
+			testConst["rate"] = rate
+
+			flowRateTest(otg.NewOtgApi(t), testConst)
+		})
+	}
+}
+
+func TestFlowRateLineRate(t *testing.T) {
+	t.Run("Percentage100", func(t *testing.T) {
+		testConst := flowRateTestConst()
+		rate := gosnappi.NewFlowRate()
+		rate.SetPercentage(100)
+		testConst["rate"] = rate
+
+		flowRateTest(otg.NewOtgApi(t), testConst)
+	})
+
+	/* rate equal to line speed excluding preamble and inter frame gap can not
+	   be achieved and is expected to be limited to line rate */
+	t.Run("GbpsLineSpeed", func(t *testing.T) {
+		testConst := flowRateTestConst()
+		api := otg.NewOtgApi(t)
+		gbps := api.Layer1SpeedToMpbs(api.TestConfig().OtgSpeed) / 1000
+		if gbps == 0 {
+			t.Skipf("Line speed %s not supported\n", api.TestConfig().OtgSpeed)
+		}
+		rate := gosnappi.NewFlowRate()
+		rate.SetGbps(uint32(gbps))
+		testConst["rate"] = rate
+
+		flowRateTest(api, testConst)
+	})
+}
+
+func flowRateTest(api *otg.OtgApi, testConst map[string]interface{}) {
+	c := flowRateConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool {
+			m := api.GetFlowMetrics()[0]
+			return m.Transmit() == gosnappi.FlowMetricTransmit.STARTED && m.FramesTx() > 0
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitStarted"},
+	)
+	time.Sleep(testConst["warmup"].(time.Duration))
+
+	rates := api.MeasureFlowRates(testConst["window"].(time.Duration))
+
+	api.StopTransmit()
+
+	api.WaitFor(
+		func() bool { return api.GetFlowMetrics()[0].Transmit() == gosnappi.FlowMetricTransmit.STOPPED },
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitStopped"},
+	)
+
+	flowRateOk(api, c, testConst, rates[c.Flows().Items()[0].Name()])
+}
+
+func flowRateConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+	p1 := c.Ports().Add().SetName("p1").SetLocation(api.TestConfig().OtgPorts[0])
+	p2 := c.Ports().Add().SetName("p2").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{p1.Name(), p2.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	f1 := c.Flows().Add().SetName("f1")
+	f1.TxRx().Port().
+		SetTxName(p1.Name()).
+		SetRxNames([]string{p2.Name()})
+	f1.Duration().Continuous()
+	f1.SetRate(tc["rate"].(gosnappi.FlowRate))
+	f1.Size().SetFixed(tc["pktSize"].(uint32))
+	f1.Metrics().SetEnable(true)
+
+	eth := f1.Packet().Add().Ethernet()
+	eth.Src().SetValue(tc["txMac"].(string))
+	eth.Dst().SetValue(tc["rxMac"].(string))
+
+	ip := f1.Packet().Add().Ipv4()
+	ip.Src().SetValue(tc["txIp"].(string))
+	ip.Dst().SetValue(tc["rxIp"].(string))
+
+	udp := f1.Packet().Add().Udp()
+	udp.SrcPort().SetValue(tc["txUdpPort"].(uint32))
+	udp.DstPort().SetValue(tc["rxUdpPort"].(uint32))
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func flowRateOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]interface{}, rates otg.FlowRates) {
+	t := api.Testing()
+	tolerance := tc["tolerance"].(float64)
+	size := float64(tc["pktSize"].(uint32))
+
+	/* configured rate is limited by line rate */
+	lineSpeedMbps := api.Layer1SpeedToMpbs(api.TestConfig().OtgSpeed)
+	linePps := float64(otg.ExpectedPps(lineSpeedMbps, 100, size))
+	expPps := math.Min(api.FlowPps(c.Flows().Items()[0]), linePps)
+	t.Logf("Expected rate: %.2f pps, line rate: %.2f pps\n", expPps, linePps)
+
+	/* reported rates are checked as is, while counter based rates are allowed
+	   to additionally deviate by error due to latency of metrics requests */
+	if math.Abs(rates.ReportedTxPps-expPps) > tolerance*expPps {
+		t.Fatalf("ERROR: Reported tx rate %.2f pps deviates from %.2f pps by more than %.2f%%\n", rates.ReportedTxPps, expPps, tolerance*100)
+	}
+	if math.Abs(rates.ReportedRxPps-rates.ReportedTxPps) > tolerance*rates.ReportedTxPps {
+		t.Fatalf("ERROR: Reported rx rate %.2f pps deviates from tx rate %.2f pps by more than %.2f%%\n", rates.ReportedRxPps, rates.ReportedTxPps, tolerance*100)
+	}
+	counterTolerance := tolerance + rates.SampleError
+	if math.Abs(rates.TxPps-expPps) > counterTolerance*expPps {
+		t.Fatalf("ERROR: Tx rate %.2f pps deviates from %.2f pps by more than %.2f%%\n", rates.TxPps, expPps, counterTolerance*100)
+	}
+	if math.Abs(rates.RxPps-rates.TxPps) > tolerance*rates.TxPps {
+		t.Fatalf("ERROR: Rx rate %.2f pps deviates from tx rate %.2f pps by more than %.2f%%\n", rates.RxPps, rates.TxPps, tolerance*100)
+	}
+
+	/* including preamble and inter frame gap, rate may not exceed line speed */
+	txMbps := rates.ReportedTxPps * (size + 12 + 8) * 8 / 1e6
+	if txMbps > float64(lineSpeedMbps)*(1+tolerance) {
+		t.Fatalf("ERROR: Tx rate %.2f Mbps exceeds line speed %d Mbps\n", txMbps, lineSpeedMbps)
+	}
+}
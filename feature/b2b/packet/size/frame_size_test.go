@@ -0,0 +1,179 @@
+//go:build all || dp
+
+package size
+
+import (
+	"testing"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func frameSizeTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktRate":   uint64(200),
+		"pktCount":  uint32(1000),
+		"txMac":     "00:00:01:01:01:01",
+		"rxMac":     "00:00:01:01:01:02",
+		"txIp":      "1.1.1.1",
+		"rxIp":      "1.1.1.2",
+		"txUdpPort": uint32(5000),
+		"rxUdpPort": uint32(6000),
+		/* allowed deviation of fraction of frames of each size */
+		"tolerance": 0.05,
+	}
+}
+
+func TestFrameSizeIncrement(t *testing.T) {
+	testConst := frameSizeTestConst()
+	/* 16 sizes from 64 to 1024 */
+	testConst["pktCount"] = uint32(160)
+	size := gosnappi.NewFlowSize()
+	size.Increment().SetStart(64).SetEnd(1024).SetStep(64)
+	testConst["size"] = size
+
+	frameSizeTest(t, testConst)
+}
+
+func TestFrameSizeRandom(t *testing.T) {
+	testConst := frameSizeTestConst()
+	size := gosnappi.NewFlowSize()
+	size.Random().SetMin(64).SetMax(1518)
+	testConst["size"] = size
+
+	frameSizeTest(t, testConst)
+}
+
+func TestFrameSizeImix(t *testing.T) {
+	for _, p := range []gosnappi.FlowSizeWeightPairsPredefinedEnum{
+		gosnappi.FlowSizeWeightPairsPredefined.IMIX,
+		gosnappi.FlowSizeWeightPairsPredefined.IPSEC_IMIX,
+		gosnappi.FlowSizeWeightPairsPredefined.IPV6_IMIX,
+		gosnappi.FlowSizeWeightPairsPredefined.STANDARD_IMIX,
+		gosnappi.FlowSizeWeightPairsPredefined.TCP_IMIX,
+	} {
+		t.Run(string(p), func(t *testing.T) {
+			testConst := frameSizeTestConst()
+			size := gosnappi.NewFlowSize()
+			size.WeightPairs().SetPredefined(p)
+			testConst["size"] = size
+
+			frameSizeTest(t, testConst)
+		})
+	}
+}
+
+func TestFrameSizeWeightPairsCustom(t *testing.T) {
+	testConst := frameSizeTestConst()
+	size := gosnappi.NewFlowSize()
+	wp := size.WeightPairs()
+	wp.Custom().Add().SetSize(64).SetWeight(1)
+	wp.Custom().Add().SetSize(512).SetWeight(2)
+	wp.Custom().Add().SetSize(1518).SetWeight(1)
+	testConst["size"] = size
+
+	frameSizeTest(t, testConst)
+}
+
+func frameSizeTest(t *testing.T, testConst map[string]interface{}) {
+	api := otg.NewOtgApi(t)
+	c := frameSizeConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartCapture()
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return frameSizeFlowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+
+	api.StopCapture()
+
+	frameSizeCaptureOk(api, c, testConst)
+}
+
+func frameSizeConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+	p1 := c.Ports().Add().SetName("p1").SetLocation(api.TestConfig().OtgPorts[0])
+	p2 := c.Ports().Add().SetName("p2").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{p1.Name(), p2.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	if api.TestConfig().OtgCaptureCheck {
+		c.Captures().Add().
+			SetName("ca").
+			SetPortNames([]string{p2.Name()}).
+			SetFormat(gosnappi.CaptureFormat.PCAP)
+	}
+
+	f1 := c.Flows().Add().SetName("f1")
+	f1.TxRx().Port().
+		SetTxName(p1.Name()).
+		SetRxNames([]string{p2.Name()})
+	f1.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+	f1.Rate().SetPps(tc["pktRate"].(uint64))
+	f1.SetSize(tc["size"].(gosnappi.FlowSize))
+	f1.Metrics().SetEnable(true)
+
+	eth := f1.Packet().Add().Ethernet()
+	eth.Src().SetValue(tc["txMac"].(string))
+	eth.Dst().SetValue(tc["rxMac"].(string))
+
+	ip := f1.Packet().Add().Ipv4()
+	ip.Src().SetValue(tc["txIp"].(string))
+	ip.Dst().SetValue(tc["rxIp"].(string))
+
+	udp := f1.Packet().Add().Udp()
+	udp.SrcPort().SetValue(tc["txUdpPort"].(uint32))
+	udp.DstPort().SetValue(tc["rxUdpPort"].(uint32))
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func frameSizeFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	m := api.GetFlowMetrics()[0]
+	expCount := uint64(tc["pktCount"].(uint32))
+
+	return m.Transmit() == gosnappi.FlowMetricTransmit.STOPPED &&
+		m.FramesTx() == expCount &&
+		m.FramesRx() == expCount
+}
+
+func frameSizeCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]interface{}) {
+	if !api.TestConfig().OtgCaptureCheck {
+		return
+	}
+	cPackets := api.GetCapture(c.Ports().Items()[1].Name())
+	t := api.Testing()
+	size := tc["size"].(gosnappi.FlowSize)
+	expCount := int(tc["pktCount"].(uint32))
+
+	if actCount := api.ValidateFrameSizes(cPackets, size, tc["txMac"].(string), tc["tolerance"].(float64)); actCount != expCount {
+		t.Fatalf("ERROR: expCount %d != actCount %d\n", expCount, actCount)
+	}
+	/* length fields of each header are validated against size of frame */
+	if actCount := api.ValidatePacketHeaders(cPackets, c.Flows().Items()[0]); actCount != expCount {
+		t.Fatalf("ERROR: expCount %d != actCount %d\n", expCount, actCount)
+	}
+
+	if size.Choice() != gosnappi.FlowSizeChoice.INCREMENT {
+		return
+	}
+	/* sizes are expected to increment in order, wrapping around after end */
+	inc := size.Increment()
+	count := int((inc.End()-inc.Start())/inc.Step()) + 1
+	j := 0
+	for i := 0; i < len(cPackets.Packets); i++ {
+		if !cPackets.HasField(t, "ethernet src", i, 6, api.MacAddrToBytes(tc["txMac"].(string))) {
+			continue
+		}
+		cPackets.ValidateSize(t, i, int(inc.Start()+inc.Step()*uint32(j%count)))
+		j += 1
+	}
+}
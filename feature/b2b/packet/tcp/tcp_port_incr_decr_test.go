@@ -148,6 +148,9 @@ func tcpPortIncrDecrCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]
 		cPackets.ValidateField(t, "tcp src", i, 34, api.Uint64ToBytes(uint64(txStart-(j%txCount)*txStep), 2))
 		cPackets.ValidateField(t, "tcp dst", i, 36, api.Uint64ToBytes(uint64(rxStart+(j%rxCount)*rxStep), 2))
 		cPackets.ValidateField(t, "tcp data offset", i, 46, api.Uint64ToBytes(uint64(80), 1))
+		// checksums
+		cPackets.ValidateIpv4Checksum(t, i, 14)
+		cPackets.ValidateTcpChecksum(t, i, 14, 34)
 	}
 	expCount := int(tc["pktCount"].(uint32))
 	actCount := len(cPackets.Packets) - ignoredCount
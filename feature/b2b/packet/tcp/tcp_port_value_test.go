@@ -119,6 +119,9 @@ func tcpPortValueCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]int
 		cPackets.ValidateField(t, "tcp src", i, 34, api.Uint64ToBytes(uint64(tc["txTcpPort"].(uint32)), 2))
 		cPackets.ValidateField(t, "tcp dst", i, 36, api.Uint64ToBytes(uint64(tc["rxTcpPort"].(uint32)), 2))
 		cPackets.ValidateField(t, "tcp data offset", i, 46, api.Uint64ToBytes(uint64(80), 1))
+		// checksums
+		cPackets.ValidateIpv4Checksum(t, i, 14)
+		cPackets.ValidateTcpChecksum(t, i, 14, 34)
 	}
 
 	expCount := int(tc["pktCount"].(uint32))
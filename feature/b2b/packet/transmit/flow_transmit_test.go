@@ -0,0 +1,305 @@
+//go:build all || dp
+
+package transmit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func flowTransmitTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"flowCount": 3,
+		"pktRate":   uint64(100),
+		"pktCount":  uint32(1000),
+		"pktSize":   uint32(128),
+		"txMac":     "00:00:01:01:01:01",
+		"rxMac":     "00:00:01:01:01:02",
+		"txIp":      "1.1.1.1",
+		"rxIp":      "1.1.1.2",
+		"txUdpPort": uint32(5000),
+		"rxUdpPort": uint32(6000),
+		/* time for which transmit of flows is observed after each change */
+		"window": 2 * time.Second,
+	}
+}
+
+func TestFlowTransmitStartSubset(t *testing.T) {
+	testConst := flowTransmitTestConst()
+	testConst["continuous"] = true
+
+	api := otg.NewOtgApi(t)
+	c := flowTransmitConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartFlows([]string{"f1"})
+
+	api.WaitFor(
+		func() bool {
+			return flowTransmitStatesOk(api, map[string]gosnappi.FlowMetricTransmitEnum{
+				"f1": gosnappi.FlowMetricTransmit.STARTED,
+				"f2": gosnappi.FlowMetricTransmit.STOPPED,
+				"f3": gosnappi.FlowMetricTransmit.STOPPED,
+			})
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitStarted"},
+	)
+
+	/* flows not started are expected to send nothing */
+	before, after := flowTransmitFramesTx(api, testConst)
+	flowTransmitProgressOk(api, before, after, []string{"f1"}, []string{"f2", "f3"})
+	for _, name := range []string{"f2", "f3"} {
+		if after[name] != 0 {
+			t.Fatalf("ERROR: Flow %s not started but sent %d frames\n", name, after[name])
+		}
+	}
+
+	api.StartFlows([]string{"f2", "f3"})
+
+	api.WaitFor(
+		func() bool {
+			return flowTransmitStatesOk(api, map[string]gosnappi.FlowMetricTransmitEnum{
+				"f1": gosnappi.FlowMetricTransmit.STARTED,
+				"f2": gosnappi.FlowMetricTransmit.STARTED,
+				"f3": gosnappi.FlowMetricTransmit.STARTED,
+			})
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitStarted"},
+	)
+
+	before, after = flowTransmitFramesTx(api, testConst)
+	flowTransmitProgressOk(api, before, after, []string{"f1", "f2", "f3"}, nil)
+
+	api.StopTransmit()
+}
+
+func TestFlowTransmitStopSubset(t *testing.T) {
+	testConst := flowTransmitTestConst()
+	testConst["continuous"] = true
+
+	api := otg.NewOtgApi(t)
+	c := flowTransmitConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool {
+			return flowTransmitStatesOk(api, map[string]gosnappi.FlowMetricTransmitEnum{
+				"f1": gosnappi.FlowMetricTransmit.STARTED,
+				"f2": gosnappi.FlowMetricTransmit.STARTED,
+				"f3": gosnappi.FlowMetricTransmit.STARTED,
+			})
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitStarted"},
+	)
+
+	api.StopFlows([]string{"f2"})
+
+	/* untouched flows are expected to keep running */
+	api.WaitFor(
+		func() bool {
+			return flowTransmitStatesOk(api, map[string]gosnappi.FlowMetricTransmitEnum{
+				"f1": gosnappi.FlowMetricTransmit.STARTED,
+				"f2": gosnappi.FlowMetricTransmit.STOPPED,
+				"f3": gosnappi.FlowMetricTransmit.STARTED,
+			})
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitStopped"},
+	)
+
+	before, after := flowTransmitFramesTx(api, testConst)
+	flowTransmitProgressOk(api, before, after, []string{"f1", "f3"}, []string{"f2"})
+
+	api.StopTransmit()
+
+	api.WaitFor(
+		func() bool {
+			return flowTransmitStatesOk(api, map[string]gosnappi.FlowMetricTransmitEnum{
+				"f1": gosnappi.FlowMetricTransmit.STOPPED,
+				"f2": gosnappi.FlowMetricTransmit.STOPPED,
+				"f3": gosnappi.FlowMetricTransmit.STOPPED,
+			})
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitStopped"},
+	)
+}
+
+func TestFlowTransmitPauseResume(t *testing.T) {
+	testConst := flowTransmitTestConst()
+	testConst["continuous"] = false
+
+	api := otg.NewOtgApi(t)
+	c := flowTransmitConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool {
+			return flowTransmitStatesOk(api, map[string]gosnappi.FlowMetricTransmitEnum{
+				"f1": gosnappi.FlowMetricTransmit.STARTED,
+				"f2": gosnappi.FlowMetricTransmit.STARTED,
+				"f3": gosnappi.FlowMetricTransmit.STARTED,
+			})
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitStarted"},
+	)
+
+	api.PauseFlows([]string{"f1", "f2"})
+
+	api.WaitFor(
+		func() bool {
+			return flowTransmitStatesOk(api, map[string]gosnappi.FlowMetricTransmitEnum{
+				"f1": gosnappi.FlowMetricTransmit.PAUSED,
+				"f2": gosnappi.FlowMetricTransmit.PAUSED,
+				"f3": gosnappi.FlowMetricTransmit.STARTED,
+			})
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitPaused"},
+	)
+
+	before, after := flowTransmitFramesTx(api, testConst)
+	flowTransmitProgressOk(api, before, after, []string{"f3"}, []string{"f1", "f2"})
+	for _, name := range []string{"f1", "f2"} {
+		if after[name] == 0 || after[name] >= uint64(testConst["pktCount"].(uint32)) {
+			t.Fatalf("ERROR: Flow %s paused after sending %d frames\n", name, after[name])
+		}
+	}
+
+	api.ResumeFlows([]string{"f1"})
+
+	api.WaitFor(
+		func() bool {
+			return flowTransmitStatesOk(api, map[string]gosnappi.FlowMetricTransmitEnum{
+				"f1": gosnappi.FlowMetricTransmit.STARTED,
+				"f2": gosnappi.FlowMetricTransmit.PAUSED,
+			})
+		},
+		&otg.WaitForOpts{FnName: "WaitForFlowTransmitResumed"},
+	)
+
+	/* resumed flow continues from where it was paused rather than restarting */
+	paused := after["f1"]
+	before, after = flowTransmitFramesTx(api, testConst)
+	flowTransmitProgressOk(api, before, after, []string{"f1"}, []string{"f2"})
+	if before["f1"] < paused {
+		t.Fatalf("ERROR: Flow f1 frames reset from %d to %d on resume\n", paused, before["f1"])
+	}
+
+	api.ResumeFlows([]string{"f2"})
+
+	/* each flow is expected to send exactly configured number of frames */
+	api.WaitFor(
+		func() bool { return flowTransmitFlowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics", Timeout: 60 * time.Second},
+	)
+}
+
+func flowTransmitConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+	p1 := c.Ports().Add().SetName("p1").SetLocation(api.TestConfig().OtgPorts[0])
+	p2 := c.Ports().Add().SetName("p2").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{p1.Name(), p2.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	for i := 1; i <= tc["flowCount"].(int); i++ {
+		f := c.Flows().Add().SetName(fmt.Sprintf("f%d", i))
+		f.TxRx().Port().
+			SetTxName(p1.Name()).
+			SetRxNames([]string{p2.Name()})
+		if tc["continuous"].(bool) {
+			f.Duration().Continuous()
+		} else {
+			f.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+		}
+		f.Rate().SetPps(tc["pktRate"].(uint64))
+		f.Size().SetFixed(tc["pktSize"].(uint32))
+		f.Metrics().SetEnable(true)
+
+		eth := f.Packet().Add().Ethernet()
+		eth.Src().SetValue(tc["txMac"].(string))
+		eth.Dst().SetValue(tc["rxMac"].(string))
+
+		ip := f.Packet().Add().Ipv4()
+		ip.Src().SetValue(tc["txIp"].(string))
+		ip.Dst().SetValue(tc["rxIp"].(string))
+
+		/* flows are distinguished by udp source port */
+		udp := f.Packet().Add().Udp()
+		udp.SrcPort().SetValue(tc["txUdpPort"].(uint32) + uint32(i))
+		udp.DstPort().SetValue(tc["rxUdpPort"].(uint32))
+	}
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+// flowTransmitStatesOk returns true if transmit state of each flow in states
+// is as expected
+func flowTransmitStatesOk(api *otg.OtgApi, states map[string]gosnappi.FlowMetricTransmitEnum) bool {
+	for _, m := range api.GetFlowMetrics() {
+		if s, ok := states[m.Name()]; ok && m.Transmit() != s {
+			return false
+		}
+	}
+
+	return true
+}
+
+// flowTransmitFramesTx returns frames sent by each flow at start and end of
+// window
+func flowTransmitFramesTx(api *otg.OtgApi, tc map[string]interface{}) (map[string]uint64, map[string]uint64) {
+	before := map[string]uint64{}
+	for _, m := range api.GetFlowMetrics() {
+		before[m.Name()] = m.FramesTx()
+	}
+
+	time.Sleep(tc["window"].(time.Duration))
+
+	after := map[string]uint64{}
+	for _, m := range api.GetFlowMetrics() {
+		after[m.Name()] = m.FramesTx()
+	}
+
+	return before, after
+}
+
+func flowTransmitProgressOk(api *otg.OtgApi, before map[string]uint64, after map[string]uint64, running []string, idle []string) {
+	t := api.Testing()
+
+	for _, name := range running {
+		if after[name] <= before[name] {
+			t.Fatalf("ERROR: Flow %s expected to be sending frames: %d -> %d\n", name, before[name], after[name])
+		}
+	}
+	for _, name := range idle {
+		if after[name] != before[name] {
+			t.Fatalf("ERROR: Flow %s not expected to be sending frames: %d -> %d\n", name, before[name], after[name])
+		}
+	}
+}
+
+func flowTransmitFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	pktCount := uint64(tc["pktCount"].(uint32))
+
+	for _, m := range api.GetFlowMetrics() {
+		if m.Transmit() != gosnappi.FlowMetricTransmit.STOPPED ||
+			m.FramesTx() != pktCount ||
+			m.FramesRx() != pktCount {
+			return false
+		}
+	}
+
+	return true
+}
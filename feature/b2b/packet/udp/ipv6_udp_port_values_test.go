@@ -121,6 +121,7 @@ func ipv6UdpPortValuesCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[strin
 		cPackets.ValidateField(t, "udp src", i, 54, api.Uint64ToBytes(uint64(txUdpPortValues[j%len(txUdpPortValues)]), 2))
 		cPackets.ValidateField(t, "udp dst", i, 56, api.Uint64ToBytes(uint64(rxUdpPortValues[j%len(rxUdpPortValues)]), 2))
 		cPackets.ValidateField(t, "udp length", i, 58, api.Uint64ToBytes(uint64(tc["pktSize"].(uint32)-14-4-40), 2))
+		cPackets.ValidateUdpChecksum(t, i, 14, 54)
 	}
 
 	expCount := int(tc["pktCount"].(uint32))
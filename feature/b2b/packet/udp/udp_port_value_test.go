@@ -119,6 +119,9 @@ func udpPortValueCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]int
 		cPackets.ValidateField(t, "udp src", i, 34, api.Uint64ToBytes(uint64(tc["txUdpPort"].(uint32)), 2))
 		cPackets.ValidateField(t, "udp dst", i, 36, api.Uint64ToBytes(uint64(tc["rxUdpPort"].(uint32)), 2))
 		cPackets.ValidateField(t, "udp length", i, 38, api.Uint64ToBytes(uint64(tc["pktSize"].(uint32)-14-4-20), 2))
+		// checksums
+		cPackets.ValidateIpv4Checksum(t, i, 14)
+		cPackets.ValidateUdpChecksum(t, i, 14, 34)
 	}
 
 	expCount := int(tc["pktCount"].(uint32))
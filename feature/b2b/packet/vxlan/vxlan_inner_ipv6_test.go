@@ -156,6 +156,10 @@ func vxlanInnerIpv6CaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]i
 		// inner tcp header
 		cPackets.ValidateField(t, "tcp src", i, 104, api.Uint64ToBytes(uint64(tc["txTcpPortValue"].(uint32)), 2))
 		cPackets.ValidateField(t, "tcp dst", i, 106, api.Uint64ToBytes(uint64(tc["rxTcpPortValue"].(uint32)), 2))
+		// checksums
+		cPackets.ValidateIpv4Checksum(t, i, 14)
+		cPackets.ValidateUdpChecksum(t, i, 14, 34)
+		cPackets.ValidateTcpChecksum(t, i, 64, 104)
 
 	}
 
@@ -0,0 +1,342 @@
+//go:build all || cpdp
+
+package rsvp
+
+import (
+	"testing"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+/* Demonstrates RSVP-TE point-to-point LSP, where tx device is the ingress
+   and rx device is the egress of an LSP signalled over directly connected
+   interfaces.
+   LSP is validated to be up via RSVP metrics, and label allocated by egress
+   (either from its label space or a fixed label) is validated via RSVP LSP
+   state of both ends.
+   MPLS labeled traffic is sent over the LSP, where the label is auto filled in
+   from the one received by ingress, and capture on receiving port validates
+   the label of each packet. */
+
+func rsvpLspTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktRate":           uint64(50),
+		"pktCount":          uint32(100),
+		"pktSize":           uint32(128),
+		"txMac":             "00:00:01:01:01:01",
+		"txIp":              "1.1.1.1",
+		"txGateway":         "1.1.1.2",
+		"txPrefix":          uint32(24),
+		"rxMac":             "00:00:01:01:01:02",
+		"rxIp":              "1.1.1.2",
+		"rxGateway":         "1.1.1.1",
+		"rxPrefix":          uint32(24),
+		"labelSpaceStart":   uint32(1000),
+		"labelSpaceEnd":     uint32(100000),
+		"tunnelId":          uint32(1),
+		"lspId":             uint32(1),
+		"refreshInterval":   uint32(30),
+		"timeoutMultiplier": uint32(3),
+		/* label is allocated from label space of egress when fixed label is 0 */
+		"fixedLabel": uint32(0),
+		"mplsTtl":    uint32(64),
+	}
+}
+
+func TestRsvpLsp(t *testing.T) {
+	testConst := rsvpLspTestConst()
+
+	rsvpLspTest(t, testConst)
+}
+
+func TestRsvpLspFixedLabel(t *testing.T) {
+	testConst := rsvpLspTestConst()
+	testConst["fixedLabel"] = uint32(5000)
+
+	rsvpLspTest(t, testConst)
+}
+
+func rsvpLspTest(t *testing.T, testConst map[string]interface{}) {
+	api := otg.NewOtgApi(t)
+	c := rsvpLspConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return rsvpLspMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForRsvpMetrics"},
+	)
+
+	label := uint32(0)
+	api.WaitFor(
+		func() bool {
+			label = rsvpLspLabel(api, testConst)
+			return label != 0
+		},
+		&otg.WaitForOpts{FnName: "WaitForRsvpLspLabel"},
+	)
+
+	rsvpLspLabelOk(api, testConst, label)
+
+	api.StartCapture()
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return rsvpLspFlowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+
+	api.StopCapture()
+
+	rsvpLspCaptureOk(api, c, testConst, label)
+}
+
+func rsvpLspConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	if api.TestConfig().OtgCaptureCheck {
+		c.Captures().Add().
+			SetName("ca").
+			SetPortNames([]string{prx.Name()}).
+			SetFormat(gosnappi.CaptureFormat.PCAP)
+	}
+
+	dtx := c.Devices().Add().SetName("dtx")
+	drx := c.Devices().Add().SetName("drx")
+
+	// transmit
+	dtxEth := dtx.Ethernets().
+		Add().
+		SetName("dtxEth").
+		SetMac(tc["txMac"].(string)).
+		SetMtu(1500)
+
+	dtxEth.Connection().SetPortName(ptx.Name())
+
+	dtxIp := dtxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("dtxIp").
+		SetAddress(tc["txIp"].(string)).
+		SetGateway(tc["txGateway"].(string)).
+		SetPrefix(tc["txPrefix"].(uint32))
+
+	dtxRsvp := dtx.Rsvp().SetName("dtxRsvp")
+
+	dtxRsvp.Ipv4Interfaces().
+		Add().
+		SetIpv4Name(dtxIp.Name()).
+		SetNeighborIp(tc["rxIp"].(string))
+
+	dtxLsp := dtxRsvp.LspIpv4Interfaces().
+		Add().
+		SetIpv4Name(dtxIp.Name()).
+		P2PIngressIpv4Lsps().
+		Add().
+		SetName("dtxLsp").
+		SetRemoteAddress(tc["rxIp"].(string)).
+		SetTunnelId(tc["tunnelId"].(uint32)).
+		SetLspId(tc["lspId"].(uint32)).
+		SetRefreshInterval(tc["refreshInterval"].(uint32)).
+		SetTimeoutMultiplier(tc["timeoutMultiplier"].(uint32))
+
+	// receive
+	drxEth := drx.Ethernets().
+		Add().
+		SetName("drxEth").
+		SetMac(tc["rxMac"].(string)).
+		SetMtu(1500)
+
+	drxEth.Connection().SetPortName(prx.Name())
+
+	drxIp := drxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("drxIp").
+		SetAddress(tc["rxIp"].(string)).
+		SetGateway(tc["rxGateway"].(string)).
+		SetPrefix(tc["rxPrefix"].(uint32))
+
+	drxRsvp := drx.Rsvp().SetName("drxRsvp")
+
+	drxRsvp.Ipv4Interfaces().
+		Add().
+		SetIpv4Name(drxIp.Name()).
+		SetNeighborIp(tc["txIp"].(string)).
+		SetLabelSpaceStart(tc["labelSpaceStart"].(uint32)).
+		SetLabelSpaceEnd(tc["labelSpaceEnd"].(uint32))
+
+	drxEgress := drxRsvp.LspIpv4Interfaces().
+		Add().
+		SetIpv4Name(drxIp.Name()).
+		P2PEgressIpv4Lsps().
+		SetName("drxEgress").
+		SetRefreshInterval(tc["refreshInterval"].(uint32)).
+		SetTimeoutMultiplier(tc["timeoutMultiplier"].(uint32)).
+		SetReservationStyle(gosnappi.RsvpLspIpv4InterfaceP2PEgressIpv4LspReservationStyle.SHARED_EXPLICIT)
+
+	if tc["fixedLabel"].(uint32) != 0 {
+		drxEgress.
+			SetEnableFixedLabel(true).
+			SetFixedLabelValue(tc["fixedLabel"].(uint32))
+	}
+
+	flow := c.Flows().Add()
+	flow.SetName("ftxLsp")
+	flow.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+	flow.Rate().SetPps(tc["pktRate"].(uint64))
+	flow.Size().SetFixed(tc["pktSize"].(uint32))
+	flow.Metrics().SetEnable(true)
+
+	flow.TxRx().Device().
+		SetTxNames([]string{dtxLsp.Name()}).
+		SetRxNames([]string{drxIp.Name()})
+
+	eth := flow.Packet().Add().Ethernet()
+	eth.Src().SetValue(dtxEth.Mac())
+	eth.Dst().SetValue(drxEth.Mac())
+
+	/* label is auto filled in from the one received by ingress in RESV */
+	mpls := flow.Packet().Add().Mpls()
+	mpls.TrafficClass().SetValue(0)
+	mpls.BottomOfStack().SetValue(1)
+	mpls.TimeToLive().SetValue(tc["mplsTtl"].(uint32))
+
+	ip := flow.Packet().Add().Ipv4()
+	ip.Src().SetValue(dtxIp.Address())
+	ip.Dst().SetValue(drxIp.Address())
+
+	udp := flow.Packet().Add().Udp()
+	udp.SrcPort().SetValue(5000)
+	udp.DstPort().SetValue(6000)
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func rsvpLspMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	t := api.Testing()
+	for _, m := range api.GetRsvpMetrics() {
+		if m.PathErrorsRx() != 0 || m.ResvErrorsRx() != 0 {
+			t.Fatalf("ERROR: RSVP %s received %d path errors and %d resv errors\n", m.Name(), m.PathErrorsRx(), m.ResvErrorsRx())
+		}
+		switch m.Name() {
+		case "dtxRsvp":
+			if m.IngressP2PLspsConfigured() != 1 || m.IngressP2PLspsUp() != 1 ||
+				m.PathsTx() < 1 || m.ResvsRx() < 1 {
+				return false
+			}
+		case "drxRsvp":
+			if m.EgressP2PLspsUp() != 1 || m.PathsRx() < 1 || m.ResvsTx() < 1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// rsvpLspLabel returns label received by ingress for the LSP if it is up, and
+// 0 otherwise
+func rsvpLspLabel(api *otg.OtgApi, tc map[string]interface{}) uint32 {
+	for _, r := range api.GetRsvpLsps() {
+		if r.RsvpRouterName() != "dtxRsvp" {
+			continue
+		}
+		for _, l := range r.Ipv4Lsps().Items() {
+			if l.SourceAddress() == tc["txIp"].(string) &&
+				l.DestinationAddress() == tc["rxIp"].(string) &&
+				l.Lsp().TunnelId() == tc["tunnelId"].(uint32) &&
+				l.Lsp().LspId() == tc["lspId"].(uint32) &&
+				l.Lsp().SessionStatus() == gosnappi.RsvpLspStateSessionStatus.UP {
+				return l.Lsp().LabelOut()
+			}
+		}
+	}
+	return 0
+}
+
+func rsvpLspLabelOk(api *otg.OtgApi, tc map[string]interface{}, label uint32) {
+	t := api.Testing()
+
+	if fixedLabel := tc["fixedLabel"].(uint32); fixedLabel != 0 {
+		if label != fixedLabel {
+			t.Fatalf("ERROR: RSVP LSP label %d != fixed label %d\n", label, fixedLabel)
+		}
+	} else if label < tc["labelSpaceStart"].(uint32) || label > tc["labelSpaceEnd"].(uint32) {
+		t.Fatalf("ERROR: RSVP LSP label %d outside of label space [%d, %d]\n", label, tc["labelSpaceStart"].(uint32), tc["labelSpaceEnd"].(uint32))
+	}
+
+	/* label advertised by egress needs to be the one used by ingress */
+	for _, r := range api.GetRsvpLsps() {
+		if r.RsvpRouterName() != "drxRsvp" {
+			continue
+		}
+		for _, l := range r.Ipv4Lsps().Items() {
+			if l.Lsp().TunnelId() == tc["tunnelId"].(uint32) &&
+				l.Lsp().LspId() == tc["lspId"].(uint32) {
+				if l.Lsp().LabelIn() != label {
+					t.Fatalf("ERROR: RSVP LSP label in %d at egress != label out %d at ingress\n", l.Lsp().LabelIn(), label)
+				}
+				return
+			}
+		}
+	}
+	t.Fatalf("ERROR: RSVP LSP with tunnel ID %d not found at egress\n", tc["tunnelId"].(uint32))
+}
+
+func rsvpLspFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	pktCount := uint64(tc["pktCount"].(uint32))
+
+	for _, m := range api.GetFlowMetrics() {
+		if m.Transmit() != gosnappi.FlowMetricTransmit.STOPPED ||
+			m.FramesTx() != pktCount ||
+			m.FramesRx() != pktCount {
+			return false
+		}
+	}
+
+	return true
+}
+
+func rsvpLspCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]interface{}, label uint32) {
+	if !api.TestConfig().OtgCaptureCheck {
+		return
+	}
+	t := api.Testing()
+	cPackets := api.GetCapture(c.Ports().Items()[1].Name())
+
+	// mpls header is label (20 bits), traffic class (3 bits), bottom of stack (1 bit) and ttl (8 bits)
+	mplsHeader := api.Uint64ToBytes(uint64(label<<12|1<<8|tc["mplsTtl"].(uint32)), 4)
+
+	expCount := int(tc["pktCount"].(uint32))
+	actCount := 0
+	for i := 0; i < len(cPackets.Packets); i++ {
+		// ignore unexpected packets based on ethernet src MAC and ethernet type
+		if cPackets.CheckField(i, 6, api.MacAddrToBytes(tc["txMac"].(string))) != nil ||
+			cPackets.CheckField(i, 12, api.Uint64ToBytes(0x8847, 2)) != nil {
+			continue
+		}
+
+		cPackets.ValidateSize(t, i, int(tc["pktSize"].(uint32)))
+		cPackets.ValidateField(t, "ethernet dst", i, 0, api.MacAddrToBytes(tc["rxMac"].(string)))
+		cPackets.ValidateField(t, "mpls header", i, 14, mplsHeader)
+		cPackets.ValidateField(t, "ipv4 src", i, 30, api.Ipv4AddrToBytes(tc["txIp"].(string)))
+		cPackets.ValidateField(t, "ipv4 dst", i, 34, api.Ipv4AddrToBytes(tc["rxIp"].(string)))
+		actCount += 1
+	}
+
+	if expCount != actCount {
+		t.Fatalf("ERROR: expCount %d != actCount %d\n", expCount, actCount)
+	}
+}
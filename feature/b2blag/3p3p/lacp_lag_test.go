@@ -0,0 +1,402 @@
+//go:build all || cpdp
+
+package static
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func lacpLagTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktRate":      uint64(1000),
+		"pktCount":     uint32(3000),
+		"pktSize":      uint32(128),
+		"txMac":        "00:00:01:01:01:01",
+		"txIp":         "1.1.1.1",
+		"rxMac":        "00:00:01:01:01:02",
+		"rxIp":         "1.1.1.2",
+		"txUdpPort":    uint32(5000),
+		"rxUdpPort":    uint32(6000),
+		"udpPortCount": uint32(64),
+		"txSystemId":   "01:01:01:01:01:01",
+		"rxSystemId":   "02:02:02:02:02:02",
+		"actorKey":     uint32(1),
+		"minLinks":     uint32(1),
+		/* lacpdu fast periodic interval (1s) and short timeout (3s) */
+		"lacpduInterval": uint32(1),
+		"lacpduTimeout":  uint32(3),
+		/* minimum share of traffic (relative to even share) each active member needs to carry */
+		"minShareRatio": float64(0.5),
+	}
+}
+
+func TestLacpLagMemberFailover(t *testing.T) {
+	testConst := lacpLagTestConst()
+
+	api := otg.NewOtgApi(t)
+	c := lacpLagConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return lacpLagMembersOk(api, testConst, nil) },
+		&otg.WaitForOpts{FnName: "WaitForLacpMetrics", Timeout: 30 * time.Second},
+	)
+	api.WaitFor(
+		func() bool { return lacpLagMetricsOk(api, gosnappi.LagMetricOperStatus.UP, 3) },
+		&otg.WaitForOpts{FnName: "WaitForLagMetrics", Timeout: 30 * time.Second},
+	)
+
+	/* stopping LACP on p3 shall take p3 and its partner p6 out of distribution */
+	api.StopLacpMemberPorts([]string{"p3"})
+
+	api.WaitFor(
+		func() bool { return lacpLagMembersOk(api, testConst, []string{"p3", "p6"}) },
+		&otg.WaitForOpts{FnName: "WaitForLacpMetricsAfterFailover", Timeout: 30 * time.Second},
+	)
+	api.WaitFor(
+		func() bool { return lacpLagMetricsOk(api, gosnappi.LagMetricOperStatus.UP, 2) },
+		&otg.WaitForOpts{FnName: "WaitForLagMetricsAfterFailover", Timeout: 30 * time.Second},
+	)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return lacpLagFlowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+
+	lacpLagMemberSharesOk(api, testConst, []string{"p1", "p2"}, []string{"p3"})
+
+	api.StartLacpMemberPorts([]string{"p3"})
+
+	api.WaitFor(
+		func() bool { return lacpLagMembersOk(api, testConst, nil) },
+		&otg.WaitForOpts{FnName: "WaitForLacpMetricsAfterRecovery", Timeout: 30 * time.Second},
+	)
+	api.WaitFor(
+		func() bool { return lacpLagMetricsOk(api, gosnappi.LagMetricOperStatus.UP, 3) },
+		&otg.WaitForOpts{FnName: "WaitForLagMetricsAfterRecovery", Timeout: 30 * time.Second},
+	)
+}
+
+func TestLacpLagMemberLinkDown(t *testing.T) {
+	testConst := lacpLagTestConst()
+
+	api := otg.NewOtgApi(t)
+	c := lacpLagConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return lacpLagMembersOk(api, testConst, nil) },
+		&otg.WaitForOpts{FnName: "WaitForLacpMetrics", Timeout: 30 * time.Second},
+	)
+	api.WaitFor(
+		func() bool { return lacpLagMetricsOk(api, gosnappi.LagMetricOperStatus.UP, 3) },
+		&otg.WaitForOpts{FnName: "WaitForLagMetrics", Timeout: 30 * time.Second},
+	)
+
+	linkDown := map[string]gosnappi.PortMetricLinkEnum{"p3": gosnappi.PortMetricLink.DOWN}
+	linkUp := map[string]gosnappi.PortMetricLinkEnum{"p3": gosnappi.PortMetricLink.UP}
+
+	/* link down on p3 shall take p3 and its partner p6 out of distribution,
+	   either through loss of link or through LACPDU timeout on p6 */
+	api.SetPortLinksDown([]string{"p3"})
+
+	api.WaitFor(
+		func() bool { return api.PortLinksOk(linkDown) },
+		&otg.WaitForOpts{FnName: "WaitForPortLinkDown"},
+	)
+	api.WaitFor(
+		func() bool { return lacpLagMembersOk(api, testConst, []string{"p3", "p6"}) },
+		&otg.WaitForOpts{FnName: "WaitForLacpMetricsAfterLinkDown", Timeout: 30 * time.Second},
+	)
+	api.WaitFor(
+		func() bool { return lacpLagMetricsOk(api, gosnappi.LagMetricOperStatus.UP, 2) },
+		&otg.WaitForOpts{FnName: "WaitForLagMetricsAfterLinkDown", Timeout: 30 * time.Second},
+	)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return lacpLagFlowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+
+	lacpLagMemberSharesOk(api, testConst, []string{"p1", "p2"}, []string{"p3"})
+
+	api.SetPortLinksUp([]string{"p3"})
+
+	api.WaitFor(
+		func() bool { return api.PortLinksOk(linkUp) },
+		&otg.WaitForOpts{FnName: "WaitForPortLinkUp"},
+	)
+	api.WaitFor(
+		func() bool { return lacpLagMembersOk(api, testConst, nil) },
+		&otg.WaitForOpts{FnName: "WaitForLacpMetricsAfterLinkUp", Timeout: 30 * time.Second},
+	)
+	api.WaitFor(
+		func() bool { return lacpLagMetricsOk(api, gosnappi.LagMetricOperStatus.UP, 3) },
+		&otg.WaitForOpts{FnName: "WaitForLagMetricsAfterLinkUp", Timeout: 30 * time.Second},
+	)
+}
+
+func TestLacpLagMinLinks(t *testing.T) {
+	testConst := lacpLagTestConst()
+	testConst["minLinks"] = uint32(3)
+
+	api := otg.NewOtgApi(t)
+	c := lacpLagConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return lacpLagMetricsOk(api, gosnappi.LagMetricOperStatus.UP, 3) },
+		&otg.WaitForOpts{FnName: "WaitForLagMetrics", Timeout: 30 * time.Second},
+	)
+
+	/* LAG shall go down as soon as active members fall below min links */
+	api.StopLacpMemberPorts([]string{"p3"})
+
+	api.WaitFor(
+		func() bool { return lacpLagMetricsOk(api, gosnappi.LagMetricOperStatus.DOWN, 2) },
+		&otg.WaitForOpts{FnName: "WaitForLagDown", Timeout: 30 * time.Second},
+	)
+
+	api.StartLacpMemberPorts([]string{"p3"})
+
+	api.WaitFor(
+		func() bool { return lacpLagMetricsOk(api, gosnappi.LagMetricOperStatus.UP, 3) },
+		&otg.WaitForOpts{FnName: "WaitForLagUp", Timeout: 30 * time.Second},
+	)
+}
+
+func lacpLagConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ports := []gosnappi.Port{}
+	for i := 0; i < 6; i++ {
+		ports = append(ports, c.Ports().Add().
+			SetName(fmt.Sprintf("p%d", i+1)).
+			SetLocation(api.TestConfig().OtgPorts[i]))
+	}
+
+	portNames := []string{}
+	for _, p := range ports {
+		portNames = append(portNames, p.Name())
+	}
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames(portNames).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	/* p1, p2, p3 are connected back-to-back to p4, p5, p6 respectively */
+	lags := []struct {
+		name     string
+		systemId string
+		ports    []gosnappi.Port
+	}{
+		{"l1", tc["txSystemId"].(string), ports[0:3]},
+		{"l2", tc["rxSystemId"].(string), ports[3:6]},
+	}
+
+	macCount := 0
+	for _, l := range lags {
+		lag := c.Lags().Add().
+			SetName(l.name).
+			SetMinLinks(tc["minLinks"].(uint32))
+
+		lag.Protocol().Lacp().
+			SetActorSystemPriority(1).
+			SetActorKey(tc["actorKey"].(uint32)).
+			SetActorSystemId(l.systemId)
+
+		for i, p := range l.ports {
+			macCount += 1
+			lagPort := lag.Ports().Add().SetPortName(p.Name())
+			lagPort.Lacp().
+				SetActorPortNumber(uint32(i + 1)).
+				SetActorPortPriority(1).
+				SetActorActivity(gosnappi.LagPortLacpActorActivity.ACTIVE).
+				SetLacpduPeriodicTimeInterval(tc["lacpduInterval"].(uint32)).
+				SetLacpduTimeout(tc["lacpduTimeout"].(uint32))
+
+			lagPort.Ethernet().
+				SetName(l.name + p.Name()).
+				SetMac(fmt.Sprintf("00:00:00:00:00:%02x", macCount)).
+				SetMtu(1500)
+		}
+	}
+
+	f1 := c.Flows().Add().SetName("f1")
+	f1.TxRx().Port().
+		SetTxName(lags[0].name).
+		SetRxName(lags[1].name)
+
+	f1.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+	f1.Rate().SetPps(tc["pktRate"].(uint64))
+	f1.Size().SetFixed(tc["pktSize"].(uint32))
+	f1.Metrics().SetEnable(true)
+
+	eth := f1.Packet().Add().Ethernet()
+	eth.Src().SetValue(tc["txMac"].(string))
+	eth.Dst().SetValue(tc["rxMac"].(string))
+
+	ip := f1.Packet().Add().Ipv4()
+	ip.Src().SetValue(tc["txIp"].(string))
+	ip.Dst().SetValue(tc["rxIp"].(string))
+
+	/* vary udp source port so that frames get hashed onto all members */
+	udp := f1.Packet().Add().Udp()
+	udp.SrcPort().Increment().
+		SetStart(tc["txUdpPort"].(uint32)).
+		SetStep(1).
+		SetCount(tc["udpPortCount"].(uint32))
+	udp.DstPort().SetValue(tc["rxUdpPort"].(uint32))
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func lacpLagMembersOk(api *otg.OtgApi, tc map[string]interface{}, downMembers []string) bool {
+	t := api.Testing()
+	/* partner of each member, as per back-to-back connection */
+	partners := map[string]string{
+		"p1": "p4", "p2": "p5", "p3": "p6",
+		"p4": "p1", "p5": "p2", "p6": "p3",
+	}
+
+	lacp := map[string]gosnappi.LacpMetric{}
+	for _, m := range api.GetLacpMetrics() {
+		lacp[m.LagMemberPortName()] = m
+	}
+
+	for name := range partners {
+		down := false
+		for _, d := range downMembers {
+			if d == name {
+				down = true
+			}
+		}
+
+		m, ok := lacp[name]
+		if down {
+			if ok && m.Distributing() {
+				t.Logf("WARNING: LAG member %s is still distributing\n", name)
+				return false
+			}
+			continue
+		}
+
+		partner, partnerOk := lacp[partners[name]]
+		if !ok || !partnerOk {
+			return false
+		}
+
+		if err := lacpLagMemberCheck(m, partner, tc); err != nil {
+			t.Logf("WARNING: LAG member %s: %v\n", name, err)
+			return false
+		}
+	}
+
+	return true
+}
+
+func lacpLagMemberCheck(m gosnappi.LacpMetric, partner gosnappi.LacpMetric, tc map[string]interface{}) error {
+	if m.Activity() != gosnappi.LacpMetricActivity.ACTIVE {
+		return fmt.Errorf("activity %s != %s", m.Activity(), gosnappi.LacpMetricActivity.ACTIVE)
+	}
+	if m.Timeout() != gosnappi.LacpMetricTimeout.SHORT {
+		return fmt.Errorf("timeout %s != %s", m.Timeout(), gosnappi.LacpMetricTimeout.SHORT)
+	}
+	if m.Synchronization() != gosnappi.LacpMetricSynchronization.IN_SYNC {
+		return fmt.Errorf("synchronization %s != %s", m.Synchronization(), gosnappi.LacpMetricSynchronization.IN_SYNC)
+	}
+	if !m.Aggregatable() || !m.Collecting() || !m.Distributing() {
+		return fmt.Errorf(
+			"aggregatable %v, collecting %v, distributing %v are not all set",
+			m.Aggregatable(), m.Collecting(), m.Distributing(),
+		)
+	}
+	if m.OperKey() != tc["actorKey"].(uint32) || m.PartnerKey() != tc["actorKey"].(uint32) {
+		return fmt.Errorf("key %d / partner key %d != %d", m.OperKey(), m.PartnerKey(), tc["actorKey"].(uint32))
+	}
+	/* partner info learnt from LACPDUs needs to match partner's own actor info */
+	if m.PartnerId() != partner.SystemId() {
+		return fmt.Errorf("partner id %s != %s", m.PartnerId(), partner.SystemId())
+	}
+	if m.PartnerPortNum() != partner.PortNum() {
+		return fmt.Errorf("partner port %d != %d", m.PartnerPortNum(), partner.PortNum())
+	}
+
+	return nil
+}
+
+func lacpLagMetricsOk(api *otg.OtgApi, expStatus gosnappi.LagMetricOperStatusEnum, expMembersUp uint32) bool {
+	for _, m := range api.GetLagMetrics() {
+		if m.OperStatus() != expStatus || m.MemberPortsUp() != expMembersUp {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lacpLagFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	m := api.GetFlowMetrics()[0]
+	expCount := uint64(tc["pktCount"].(uint32))
+
+	return m.Transmit() == gosnappi.FlowMetricTransmit.STOPPED &&
+		m.FramesTx() == expCount &&
+		m.FramesRx() == expCount
+}
+
+func lacpLagMemberSharesOk(api *otg.OtgApi, tc map[string]interface{}, activeMembers []string, inactiveMembers []string) {
+	t := api.Testing()
+	pktCount := float64(tc["pktCount"].(uint32))
+
+	/* data frames sent on a member are total frames excluding LACPDUs */
+	lacpduTx := map[string]uint64{}
+	for _, m := range api.GetLacpMetrics() {
+		lacpduTx[m.LagMemberPortName()] = m.LacpPacketsTx()
+	}
+	dataTx := map[string]float64{}
+	for _, m := range api.GetPortMetrics() {
+		if m.FramesTx() > lacpduTx[m.Name()] {
+			dataTx[m.Name()] = float64(m.FramesTx() - lacpduTx[m.Name()])
+		} else {
+			dataTx[m.Name()] = 0
+		}
+	}
+
+	minShare := tc["minShareRatio"].(float64) / float64(len(activeMembers))
+	for _, name := range activeMembers {
+		share := dataTx[name] / pktCount
+		t.Logf("LAG member %s carried %.2f%% of traffic\n", name, share*100)
+		if share < minShare {
+			t.Fatalf("ERROR: LAG member %s share %.4f < expected min share %.4f\n", name, share, minShare)
+		}
+	}
+
+	/* allow for a few LACPDUs sent in between fetching LACP and port metrics */
+	for _, name := range inactiveMembers {
+		share := dataTx[name] / pktCount
+		t.Logf("LAG member %s carried %.2f%% of traffic\n", name, share*100)
+		if share > 0.01 {
+			t.Fatalf("ERROR: inactive LAG member %s share %.4f > 0\n", name, share)
+		}
+	}
+}
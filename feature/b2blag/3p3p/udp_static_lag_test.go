@@ -4,6 +4,7 @@ package static
 
 import (
 	"testing"
+	"time"
 
 	"github.com/open-traffic-generator/conformance/helpers/otg"
 	"github.com/open-traffic-generator/snappi/gosnappi"
@@ -20,6 +21,10 @@ func TestUdpStaticLag(t *testing.T) {
 		"rxIp":      "1.1.1.2",
 		"txUdpPort": uint32(5000),
 		"rxUdpPort": uint32(6000),
+		/* udp source port is incremented so that frames get hashed onto all members */
+		"txUdpPortCount": uint32(64),
+		/* minimum share of traffic (relative to even share) each member needs to carry */
+		"minShareRatio": float64(0.5),
 	}
 
 	api := otg.NewOtgApi(t)
@@ -28,9 +33,13 @@ func TestUdpStaticLag(t *testing.T) {
 	api.SetConfig(c)
 
 	api.StartCapture()
-	// TODO: do we need to wait after starting protocols ?
 	api.StartProtocols()
-	// TODO: do we need to check LAG metrics ?
+
+	api.WaitFor(
+		func() bool { return udpStaticLagLagMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForLagMetrics", Timeout: 30 * time.Second},
+	)
+
 	api.StartTransmit()
 
 	api.WaitFor(
@@ -40,8 +49,7 @@ func TestUdpStaticLag(t *testing.T) {
 
 	api.StopCapture()
 
-	// TODO: check capture on correct port
-	// udpStaticLagCaptureOk(api, c, testConst)
+	udpStaticLagCaptureOk(api, c, testConst)
 }
 
 func udpStaticLagConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
@@ -91,7 +99,7 @@ func udpStaticLagConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Con
 	if api.TestConfig().OtgCaptureCheck {
 		c.Captures().Add().
 			SetName("ca").
-			SetPortNames([]string{p1.Name(), p4.Name()}).
+			SetPortNames([]string{p4.Name(), p5.Name(), p6.Name()}).
 			SetFormat(gosnappi.CaptureFormat.PCAP)
 	}
 
@@ -114,7 +122,10 @@ func udpStaticLagConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Con
 	ip.Dst().SetValue(tc["rxIp"].(string))
 
 	udp := f1.Packet().Add().Udp()
-	udp.SrcPort().SetValue(tc["txUdpPort"].(uint32))
+	udp.SrcPort().Increment().
+		SetStart(tc["txUdpPort"].(uint32)).
+		SetStep(1).
+		SetCount(tc["txUdpPortCount"].(uint32))
 	udp.DstPort().SetValue(tc["rxUdpPort"].(uint32))
 
 	api.Testing().Logf("Config:\n%v\n", c)
@@ -130,39 +141,80 @@ func udpStaticLagFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool
 		m.FramesRx() == expCount
 }
 
+func udpStaticLagLagMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	for _, m := range api.GetLagMetrics() {
+		if m.OperStatus() != gosnappi.LagMetricOperStatus.UP || m.MemberPortsUp() != 3 {
+			return false
+		}
+	}
+
+	return true
+}
+
 func udpStaticLagCaptureOk(api *otg.OtgApi, c gosnappi.Config, tc map[string]interface{}) {
 	if !api.TestConfig().OtgCaptureCheck {
 		return
 	}
-	ignoredCount := 0
-	cPackets := api.GetCapture(c.Ports().Items()[3].Name())
 	t := api.Testing()
 
-	for i := 0; i < len(cPackets.Packets); i++ {
-		// ignore unexpected packets based on ethernet src MAC
-		if !cPackets.HasField(t, "ethernet src", i, 6, api.MacAddrToBytes(tc["txMac"].(string))) {
-			ignoredCount += 1
-			continue
+	startPort := uint64(tc["txUdpPort"].(uint32))
+	endPort := startPort + uint64(tc["txUdpPortCount"].(uint32))
+	/* member on which frames for a given udp source port were received */
+	portMembers := map[uint64]string{}
+	memberCounts := map[string]int{}
+	members := c.Captures().Items()[0].PortNames()
+
+	for _, member := range members {
+		cPackets := api.GetCapture(member)
+
+		for i := 0; i < len(cPackets.Packets); i++ {
+			// ignore unexpected packets based on ethernet src MAC
+			if !cPackets.HasField(t, "ethernet src", i, 6, api.MacAddrToBytes(tc["txMac"].(string))) {
+				continue
+			}
+			// packet size
+			cPackets.ValidateSize(t, i, int(tc["pktSize"].(uint32)))
+			// ethernet header
+			cPackets.ValidateField(t, "ethernet dst", i, 0, api.MacAddrToBytes(tc["rxMac"].(string)))
+			cPackets.ValidateField(t, "ethernet type", i, 12, api.Uint64ToBytes(2048, 2))
+			// ipv4 header
+			cPackets.ValidateField(t, "ipv4 total length", i, 16, api.Uint64ToBytes(uint64(tc["pktSize"].(uint32)-14-4), 2))
+			cPackets.ValidateField(t, "ipv4 protocol", i, 23, api.Uint64ToBytes(17, 1))
+			cPackets.ValidateField(t, "ipv4 src", i, 26, api.Ipv4AddrToBytes(tc["txIp"].(string)))
+			cPackets.ValidateField(t, "ipv4 dst", i, 30, api.Ipv4AddrToBytes(tc["rxIp"].(string)))
+			// udp header
+			cPackets.ValidateField(t, "udp dst", i, 36, api.Uint64ToBytes(uint64(tc["rxUdpPort"].(uint32)), 2))
+			cPackets.ValidateField(t, "udp length", i, 38, api.Uint64ToBytes(uint64(tc["pktSize"].(uint32)-14-4-20), 2))
+
+			data := cPackets.Packets[i].Data
+			srcPort := uint64(data[34])<<8 | uint64(data[35])
+			if srcPort < startPort || srcPort >= endPort {
+				t.Fatalf("ERROR: udp src %d not in range [%d, %d)\n", srcPort, startPort, endPort)
+			}
+
+			// frames with same hash inputs need to land on same member
+			if prev, ok := portMembers[srcPort]; ok && prev != member {
+				t.Fatalf("ERROR: udp src %d received on both %s and %s\n", srcPort, prev, member)
+			}
+			portMembers[srcPort] = member
+			memberCounts[member] += 1
 		}
-		// packet size
-		cPackets.ValidateSize(t, i, int(tc["pktSize"].(uint32)))
-		// ethernet header
-		cPackets.ValidateField(t, "ethernet dst", i, 0, api.MacAddrToBytes(tc["rxMac"].(string)))
-		cPackets.ValidateField(t, "ethernet type", i, 12, api.Uint64ToBytes(2048, 2))
-		// ipv4 header
-		cPackets.ValidateField(t, "ipv4 total length", i, 16, api.Uint64ToBytes(uint64(tc["pktSize"].(uint32)-14-4), 2))
-		cPackets.ValidateField(t, "ipv4 protocol", i, 23, api.Uint64ToBytes(17, 1))
-		cPackets.ValidateField(t, "ipv4 src", i, 26, api.Ipv4AddrToBytes(tc["txIp"].(string)))
-		cPackets.ValidateField(t, "ipv4 dst", i, 30, api.Ipv4AddrToBytes(tc["rxIp"].(string)))
-		// udp header
-		cPackets.ValidateField(t, "udp src", i, 34, api.Uint64ToBytes(uint64(tc["txUdpPort"].(uint32)), 2))
-		cPackets.ValidateField(t, "udp dst", i, 36, api.Uint64ToBytes(uint64(tc["rxUdpPort"].(uint32)), 2))
-		cPackets.ValidateField(t, "udp length", i, 38, api.Uint64ToBytes(uint64(tc["pktSize"].(uint32)-14-4-20), 2))
 	}
 
 	expCount := int(tc["pktCount"].(uint32))
-	actCount := len(cPackets.Packets) - ignoredCount
+	actCount := 0
+	for _, member := range members {
+		actCount += memberCounts[member]
+	}
 	if expCount != actCount {
 		t.Fatalf("ERROR: expCount %d != actCount %d\n", expCount, actCount)
 	}
+
+	minCount := int(tc["minShareRatio"].(float64) * float64(expCount) / float64(len(members)))
+	for _, member := range members {
+		t.Logf("LAG member %s received %d of %d frames\n", member, memberCounts[member], expCount)
+		if memberCounts[member] < minCount {
+			t.Fatalf("ERROR: LAG member %s count %d < expected min count %d\n", member, memberCounts[member], minCount)
+		}
+	}
 }
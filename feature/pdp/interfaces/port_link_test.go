@@ -0,0 +1,89 @@
+//go:build all || cpdp
+
+package interfaces
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+/* Demonstrates link down and up towards DUT, where link of transmitting port
+   is brought down and up while traffic is being routed by DUT. */
+
+func TestPortLinkDownUp(t *testing.T) {
+
+	testConst := map[string]interface{}{
+		"pktRate":   uint64(100),
+		"pktCount":  uint32(100),
+		"pktSize":   uint32(128),
+		"txMac":     "00:00:01:01:01:01",
+		"txIp":      "1.1.1.1",
+		"txGateway": "1.1.1.2",
+		"txPrefix":  uint32(24),
+		"rxMac":     "00:00:01:01:01:02",
+		"rxIp":      "2.2.2.1",
+		"rxGateway": "2.2.2.2",
+		"rxPrefix":  uint32(24),
+		/* time for which traffic is observed after each link change */
+		"window": 2 * time.Second,
+	}
+
+	api := otg.NewOtgApi(t)
+
+	rmDutConfig := ipNeighborsDutConfig(api, testConst)
+	defer rmDutConfig()
+
+	c := ipNeighborsConfig(api, testConst)
+	/* traffic is kept running across link changes */
+	c.Flows().Items()[0].Duration().Continuous()
+	api.SetConfig(c)
+
+	api.WaitFor(
+		func() bool { return ipNeighborsIpv4NeighborsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForIpv4Neighbors"},
+	)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return api.GetFlowMetrics()[0].FramesRx() > 0 },
+		&otg.WaitForOpts{FnName: "WaitForFlowFramesRx"},
+	)
+
+	linkDown := map[string]gosnappi.PortMetricLinkEnum{"ptx": gosnappi.PortMetricLink.DOWN}
+	linkUp := map[string]gosnappi.PortMetricLinkEnum{"ptx": gosnappi.PortMetricLink.UP}
+
+	api.SetPortLinksDown([]string{"ptx"})
+
+	api.WaitFor(
+		func() bool { return api.PortLinksOk(linkDown) },
+		&otg.WaitForOpts{FnName: "WaitForPortLinkDown"},
+	)
+
+	/* no frames are expected to be routed by DUT while link is down */
+	if frames := api.FlowFramesRxOver(testConst["window"].(time.Duration))["ftxV4"]; frames != 0 {
+		t.Fatalf("ERROR: %d frames received while link is down\n", frames)
+	}
+
+	api.SetPortLinksUp([]string{"ptx"})
+
+	api.WaitFor(
+		func() bool { return api.PortLinksOk(linkUp) },
+		&otg.WaitForOpts{FnName: "WaitForPortLinkUp"},
+	)
+
+	/* gateway is expected to be resolved again before traffic is restored */
+	api.WaitFor(
+		func() bool { return ipNeighborsIpv4NeighborsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForIpv4Neighbors", Timeout: 30 * time.Second},
+	)
+	api.WaitFor(
+		func() bool { return api.FlowFramesRxOver(testConst["window"].(time.Duration))["ftxV4"] > 0 },
+		&otg.WaitForOpts{FnName: "WaitForFlowFramesRx", Timeout: 30 * time.Second},
+	)
+
+	api.StopTransmit()
+}
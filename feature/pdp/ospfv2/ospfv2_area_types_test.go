@@ -0,0 +1,493 @@
+//go:build all || cpdp
+
+package ospfv2
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/dut"
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+/* Demonstrates OSPFv2 area types across an area border router, where DUT is
+   the ABR with one interface in backbone area towards tx router and another
+   in normal, stub or NSSA area towards rx router.
+   Tx router is an ASBR in backbone, originating an intra-area and an external
+   route, while rx router originates an intra-area route, and an NSSA external
+   route when in NSSA.
+   Tests assert on summary, external and NSSA LSAs generated, translated and
+   filtered by DUT, and on traffic routed by DUT between areas. */
+
+func ospfv2AreaTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktRate":       uint64(50),
+		"pktCount":      uint32(100),
+		"pktSize":       uint32(128),
+		"txMac":         "00:00:01:01:01:01",
+		"txIp":          "1.1.1.1",
+		"txGateway":     "1.1.1.2",
+		"txPrefix":      uint32(24),
+		"rxMac":         "00:00:01:01:01:02",
+		"rxIp":          "2.2.2.1",
+		"rxGateway":     "2.2.2.2",
+		"rxPrefix":      uint32(24),
+		"txRouterName":  "dtx_ospfv2",
+		"rxRouterName":  "drx_ospfv2",
+		"dutRouterId":   "3.3.3.3",
+		"areaId":        uint32(1),
+		"dutAreaType":   "",
+		"eBit":          true,
+		"npBit":         false,
+		"metric":        uint32(10),
+		"txAdvRouteV4":  "10.10.10.1",
+		"txExtRouteV4":  "10.10.20.1",
+		"rxAdvRouteV4":  "20.20.20.1",
+		"rxNssaRouteV4": "20.20.30.1",
+		/* type 5 LSAs from tx router flooded by DUT into rx area */
+		"externalLsas": 1,
+		/* default route advertised by DUT into rx area as type 3 or type 7 LSA */
+		"defaultSummaryLsas": 0,
+		"defaultNssaLsas":    0,
+		/* type 7 LSAs from rx router translated by DUT into type 5 LSAs */
+		"translatedLsas": 0,
+	}
+}
+
+func TestOspfv2AbrNormalArea(t *testing.T) {
+	testConst := ospfv2AreaTestConst()
+
+	ospfv2AreaTest(t, testConst)
+}
+
+func TestOspfv2AbrStubArea(t *testing.T) {
+	testConst := ospfv2AreaTestConst()
+	/* stub area is signalled by clearing E-bit in hello options */
+	testConst["dutAreaType"] = "stub"
+	testConst["eBit"] = false
+	/* type 5 LSAs are replaced by a default type 3 LSA at stub area boundary */
+	testConst["externalLsas"] = 0
+	testConst["defaultSummaryLsas"] = 1
+
+	ospfv2AreaTest(t, testConst)
+}
+
+func TestOspfv2AbrNssaArea(t *testing.T) {
+	testConst := ospfv2AreaTestConst()
+	/* NSSA is signalled by clearing E-bit and setting N/P-bit in hello options */
+	testConst["dutAreaType"] = "nssa default-information-originate"
+	testConst["eBit"] = false
+	testConst["npBit"] = true
+	/* type 5 LSAs are blocked at NSSA boundary, where DUT instead originates
+	   a default type 7 LSA, and translates type 7 LSAs into backbone */
+	testConst["externalLsas"] = 0
+	testConst["defaultNssaLsas"] = 1
+	testConst["translatedLsas"] = 1
+
+	ospfv2AreaTest(t, testConst)
+}
+
+func ospfv2AreaTest(t *testing.T, testConst map[string]interface{}) {
+	api := otg.NewOtgApi(t)
+
+	rmDutConfig := ospfv2AreaDutConfig(api, testConst)
+	defer rmDutConfig()
+
+	c := ospfv2AreaConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return ospfv2AreaMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForOspfv2Metrics", Timeout: 60 * time.Second},
+	)
+
+	api.WaitFor(
+		func() bool { return ospfv2AreaLsasOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForOspfv2Lsas", Timeout: 30 * time.Second},
+	)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return ospfv2AreaFlowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+}
+
+func ospfv2AreaDutConfig(api *otg.OtgApi, tc map[string]interface{}) func() {
+	dc := &api.TestConfig().DutConfigs[0]
+
+	_, txNet, _ := net.ParseCIDR(fmt.Sprintf("%s/%d", tc["txGateway"].(string), tc["txPrefix"].(uint32)))
+	_, rxNet, _ := net.ParseCIDR(fmt.Sprintf("%s/%d", tc["rxGateway"].(string), tc["rxPrefix"].(uint32)))
+
+	areaCfg := ""
+	if tc["dutAreaType"].(string) != "" {
+		areaCfg = fmt.Sprintf("area %d %s", tc["areaId"].(uint32), tc["dutAreaType"].(string))
+	}
+
+	setCfg := fmt.Sprintf(`
+		router ospf 1
+			router-id %s
+			%s
+			network %s area 0
+			network %s area %d
+		!
+		interface %s
+			no switchport
+			ip address %s/%d
+			ip ospf network point-to-point
+		!
+		interface %s
+			no switchport
+			ip address %s/%d
+			ip ospf network point-to-point
+		!
+	`,
+		tc["dutRouterId"].(string),
+		areaCfg,
+		txNet,
+		rxNet,
+		tc["areaId"].(uint32),
+		dc.Interfaces[0],
+		tc["txGateway"].(string),
+		tc["txPrefix"].(uint32),
+		dc.Interfaces[1],
+		tc["rxGateway"].(string),
+		tc["rxPrefix"].(uint32),
+	)
+
+	unsetCfg := fmt.Sprintf(`
+		no router ospf 1
+		!
+		interface %s
+			no ip ospf network point-to-point
+			no ip address
+		!
+		interface %s
+			no ip ospf network point-to-point
+			no ip address
+		!
+	`,
+		dc.Interfaces[0],
+		dc.Interfaces[1],
+	)
+
+	return dut.NewDutApi(api.Testing(), dc).SetSshConfig(setCfg, unsetCfg)
+}
+
+func ospfv2AreaConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	dtx := c.Devices().Add().SetName("dtx")
+	drx := c.Devices().Add().SetName("drx")
+
+	// transmit
+	dtxEth := dtx.Ethernets().
+		Add().
+		SetName("dtxEth").
+		SetMac(tc["txMac"].(string)).
+		SetMtu(1500)
+
+	dtxEth.Connection().SetPortName(ptx.Name())
+
+	dtxIp := dtxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("dtxIp").
+		SetAddress(tc["txIp"].(string)).
+		SetGateway(tc["txGateway"].(string)).
+		SetPrefix(tc["txPrefix"].(uint32))
+
+	dtxOspfv2 := dtx.Ospfv2().
+		SetName(tc["txRouterName"].(string)).
+		SetStoreLsa(true)
+
+	/* tx router is an AS boundary router in backbone */
+	dtxOspfv2.Capabilities().
+		SetLsaEBit(true)
+
+	dtxOspfv2Int := dtxOspfv2.
+		Interfaces().
+		Add().
+		SetName("dtxOspfv2Int").
+		SetIpv4Name(dtxIp.Name())
+
+	dtxOspfv2Int.Area().SetId(0)
+	dtxOspfv2Int.NetworkType().PointToPoint()
+
+	dtxOspfv2RrV4 := dtxOspfv2.
+		V4Routes().
+		Add().
+		SetName("dtxOspfv2RrV4").
+		SetMetric(tc["metric"].(uint32))
+
+	dtxOspfv2RrV4.
+		Addresses().
+		Add().
+		SetAddress(tc["txAdvRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(1).
+		SetStep(1)
+
+	dtxOspfv2RrV4.RouteOrigin().IntraArea()
+
+	dtxOspfv2RrExt := dtxOspfv2.
+		V4Routes().
+		Add().
+		SetName("dtxOspfv2RrExt").
+		SetMetric(tc["metric"].(uint32))
+
+	dtxOspfv2RrExt.
+		Addresses().
+		Add().
+		SetAddress(tc["txExtRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(1).
+		SetStep(1)
+
+	dtxOspfv2RrExt.RouteOrigin().ExternalType2()
+
+	// recieve
+	drxEth := drx.Ethernets().
+		Add().
+		SetName("drxEth").
+		SetMac(tc["rxMac"].(string)).
+		SetMtu(1500)
+
+	drxEth.Connection().SetPortName(prx.Name())
+
+	drxIp := drxEth.
+		Ipv4Addresses().
+		Add().
+		SetName("drxIp").
+		SetAddress(tc["rxIp"].(string)).
+		SetGateway(tc["rxGateway"].(string)).
+		SetPrefix(tc["rxPrefix"].(uint32))
+
+	drxOspfv2 := drx.Ospfv2().
+		SetName(tc["rxRouterName"].(string)).
+		SetStoreLsa(true)
+
+	/* options need to match with DUT for adjacency to come up */
+	drxOspfv2.Capabilities().
+		SetEBit(tc["eBit"].(bool)).
+		SetNpBit(tc["npBit"].(bool))
+
+	drxOspfv2Int := drxOspfv2.
+		Interfaces().
+		Add().
+		SetName("drxOspfv2Int").
+		SetIpv4Name(drxIp.Name())
+
+	drxOspfv2Int.Area().SetId(tc["areaId"].(uint32))
+	drxOspfv2Int.NetworkType().PointToPoint()
+
+	drxOspfv2RrV4 := drxOspfv2.
+		V4Routes().
+		Add().
+		SetName("drxOspfv2RrV4").
+		SetMetric(tc["metric"].(uint32))
+
+	drxOspfv2RrV4.
+		Addresses().
+		Add().
+		SetAddress(tc["rxAdvRouteV4"].(string)).
+		SetPrefix(32).
+		SetCount(1).
+		SetStep(1)
+
+	drxOspfv2RrV4.RouteOrigin().IntraArea()
+
+	// traffic
+	type areaFlow struct {
+		name    string
+		txRoute gosnappi.Ospfv2V4RouteRange
+		rxRoute gosnappi.Ospfv2V4RouteRange
+		mac     string
+		src     string
+		dst     string
+	}
+	flows := []areaFlow{
+		{"ftxV4", dtxOspfv2RrV4, drxOspfv2RrV4, dtxEth.Mac(), tc["txAdvRouteV4"].(string), tc["rxAdvRouteV4"].(string)},
+		{"frxV4", drxOspfv2RrV4, dtxOspfv2RrV4, drxEth.Mac(), tc["rxAdvRouteV4"].(string), tc["txAdvRouteV4"].(string)},
+	}
+
+	if tc["npBit"].(bool) {
+		/* rx router is an AS boundary router in NSSA */
+		drxOspfv2.Capabilities().SetLsaEBit(true)
+
+		drxOspfv2RrNssa := drxOspfv2.
+			V4Routes().
+			Add().
+			SetName("drxOspfv2RrNssa").
+			SetMetric(tc["metric"].(uint32))
+
+		drxOspfv2RrNssa.
+			Addresses().
+			Add().
+			SetAddress(tc["rxNssaRouteV4"].(string)).
+			SetPrefix(32).
+			SetCount(1).
+			SetStep(1)
+
+		drxOspfv2RrNssa.RouteOrigin().NssaExternal().SetPropagation(true)
+
+		flows = append(flows, areaFlow{
+			"ftxV4Nssa", dtxOspfv2RrV4, drxOspfv2RrNssa, dtxEth.Mac(), tc["txAdvRouteV4"].(string), tc["rxNssaRouteV4"].(string),
+		})
+	}
+
+	for _, f := range flows {
+		flow := c.Flows().Add().SetName(f.name)
+		flow.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+		flow.Rate().SetPps(tc["pktRate"].(uint64))
+		flow.Size().SetFixed(tc["pktSize"].(uint32))
+		flow.Metrics().SetEnable(true)
+
+		flow.TxRx().Device().
+			SetTxNames([]string{f.txRoute.Name()}).
+			SetRxNames([]string{f.rxRoute.Name()})
+
+		flowEth := flow.Packet().Add().Ethernet()
+		flowEth.Src().SetValue(f.mac)
+
+		flowIp := flow.Packet().Add().Ipv4()
+		flowIp.Src().SetValue(f.src)
+		flowIp.Dst().SetValue(f.dst)
+
+		flowTcp := flow.Packet().Add().Tcp()
+		flowTcp.SrcPort().SetValue(5000)
+		flowTcp.DstPort().SetValue(6000)
+	}
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func ospfv2AreaMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	/* LSAs filtered at area boundary must not be received at all */
+	countOk := func(act uint64, exp int) bool {
+		if exp == 0 {
+			return act == 0
+		}
+		return act >= uint64(exp)
+	}
+
+	count := 0
+	for _, m := range api.GetOspfv2Metrics() {
+		switch m.Name() {
+		case tc["txRouterName"].(string):
+			/* type 7 LSAs never leave NSSA */
+			if m.FullStateCount() < 1 ||
+				m.SummaryLsaReceived() < 1 ||
+				m.ExternalLsaReceived() < uint64(tc["translatedLsas"].(int)) ||
+				m.NssaLsaReceived() != 0 {
+				return false
+			}
+		case tc["rxRouterName"].(string):
+			if m.FullStateCount() < 1 ||
+				m.SummaryLsaReceived() < 1 ||
+				!countOk(m.ExternalLsaReceived(), tc["externalLsas"].(int)) ||
+				!countOk(m.NssaLsaReceived(), tc["defaultNssaLsas"].(int)) {
+				return false
+			}
+		default:
+			continue
+		}
+		count += 1
+	}
+	return count == 2
+}
+
+func ospfv2AreaLsasOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	t := api.Testing()
+	lsas := otg.FlattenOspfv2Lsas(api.GetOspfv2Lsas())
+
+	txRouterName := tc["txRouterName"].(string)
+	rxRouterName := tc["rxRouterName"].(string)
+	dutRouterId := tc["dutRouterId"].(string)
+
+	for _, e := range []struct {
+		match otg.OspfLsaMatch
+		count int
+	}{
+		/* summaries generated by DUT for intra-area routes of each area */
+		{otg.OspfLsaMatch{
+			RouterName:          rxRouterName,
+			Type:                otg.OspfLsaNetworkSummary,
+			AdvertisingRouterId: dutRouterId,
+			Prefix:              tc["txAdvRouteV4"].(string) + "/32",
+		}, 1},
+		{otg.OspfLsaMatch{
+			RouterName:          txRouterName,
+			Type:                otg.OspfLsaNetworkSummary,
+			AdvertisingRouterId: dutRouterId,
+			Prefix:              tc["rxAdvRouteV4"].(string) + "/32",
+		}, 1},
+		/* external LSAs are flooded unchanged, unless blocked at area boundary */
+		{otg.OspfLsaMatch{RouterName: rxRouterName, Type: otg.OspfLsaExternalAs}, tc["externalLsas"].(int)},
+		{otg.OspfLsaMatch{
+			RouterName:          rxRouterName,
+			Type:                otg.OspfLsaExternalAs,
+			AdvertisingRouterId: tc["txIp"].(string),
+			Prefix:              tc["txExtRouteV4"].(string) + "/32",
+			Metric:              tc["metric"].(uint32),
+			MetricType:          2,
+		}, tc["externalLsas"].(int)},
+		/* default route replacing external routes in stub area or NSSA */
+		{otg.OspfLsaMatch{
+			RouterName:          rxRouterName,
+			Type:                otg.OspfLsaNetworkSummary,
+			AdvertisingRouterId: dutRouterId,
+			Prefix:              "0.0.0.0/0",
+		}, tc["defaultSummaryLsas"].(int)},
+		{otg.OspfLsaMatch{
+			RouterName:          rxRouterName,
+			Type:                otg.OspfLsaNssa,
+			AdvertisingRouterId: dutRouterId,
+			Prefix:              "0.0.0.0/0",
+		}, tc["defaultNssaLsas"].(int)},
+		/* type 7 LSA of rx router translated by DUT into backbone */
+		{otg.OspfLsaMatch{RouterName: txRouterName, Type: otg.OspfLsaNssa}, 0},
+		{otg.OspfLsaMatch{
+			RouterName:          txRouterName,
+			Type:                otg.OspfLsaExternalAs,
+			AdvertisingRouterId: dutRouterId,
+			Prefix:              tc["rxNssaRouteV4"].(string) + "/32",
+		}, tc["translatedLsas"].(int)},
+	} {
+		if !e.match.Has(t, lsas, e.count) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func ospfv2AreaFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	pktCount := uint64(tc["pktCount"].(uint32))
+
+	for _, m := range api.GetFlowMetrics() {
+		if m.Transmit() != gosnappi.FlowMetricTransmit.STOPPED ||
+			m.FramesTx() != pktCount ||
+			m.FramesRx() != pktCount {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,501 @@
+//go:build all || cpdp
+
+package ospfv3
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/dut"
+	"github.com/open-traffic-generator/conformance/helpers/otg"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+/* Demonstrates OSPFv3 area types across an area border router, where DUT is
+   the ABR with one interface in backbone area towards tx router and another
+   in normal, stub or NSSA area towards rx router.
+   Tx router is an ASBR in backbone, originating an intra-area and an external
+   route, while rx router originates an intra-area route, and an NSSA external
+   route when in NSSA.
+   Tests assert on inter-area prefix, external and NSSA LSAs generated,
+   translated and filtered by DUT, and on traffic routed by DUT between areas. */
+
+func ospfv3AreaTestConst() map[string]interface{} {
+	return map[string]interface{}{
+		"pktRate":       uint64(50),
+		"pktCount":      uint32(100),
+		"pktSize":       uint32(128),
+		"txMac":         "00:00:01:01:01:01",
+		"txIpV6":        "2001:db8:1::1",
+		"txGateway":     "2001:db8:1::2",
+		"txPrefix":      uint32(64),
+		"rxMac":         "00:00:01:01:01:02",
+		"rxIpV6":        "2001:db8:2::1",
+		"rxGateway":     "2001:db8:2::2",
+		"rxPrefix":      uint32(64),
+		"txRouterName":  "dtx_ospfv3",
+		"rxRouterName":  "drx_ospfv3",
+		"txRouterId":    "5.5.5.5",
+		"rxRouterId":    "7.7.7.7",
+		"dutRouterId":   "3.3.3.3",
+		"areaId":        uint32(1),
+		"dutAreaType":   "",
+		"eBit":          true,
+		"nBit":          false,
+		"metric":        uint32(10),
+		"txAdvRouteV6":  "4:4:1:0:0:0:0:1",
+		"txExtRouteV6":  "4:4:2:0:0:0:0:1",
+		"rxAdvRouteV6":  "6:6:1:0:0:0:0:1",
+		"rxNssaRouteV6": "6:6:2:0:0:0:0:1",
+		/* AS-external LSAs from tx router flooded by DUT into rx area */
+		"externalLsas": 1,
+		/* default route advertised by DUT into rx area as inter-area prefix or
+		   NSSA LSA */
+		"defaultInterAreaPrefixLsas": 0,
+		"defaultNssaLsas":            0,
+		/* NSSA LSAs from rx router translated by DUT into AS-external LSAs */
+		"translatedLsas": 0,
+	}
+}
+
+func TestOspfv3AbrNormalArea(t *testing.T) {
+	testConst := ospfv3AreaTestConst()
+
+	ospfv3AreaTest(t, testConst)
+}
+
+func TestOspfv3AbrStubArea(t *testing.T) {
+	testConst := ospfv3AreaTestConst()
+	/* stub area is signalled by clearing E-bit in hello options */
+	testConst["dutAreaType"] = "stub"
+	testConst["eBit"] = false
+	/* AS-external LSAs are replaced by a default inter-area prefix LSA at stub
+	   area boundary */
+	testConst["externalLsas"] = 0
+	testConst["defaultInterAreaPrefixLsas"] = 1
+
+	ospfv3AreaTest(t, testConst)
+}
+
+func TestOspfv3AbrNssaArea(t *testing.T) {
+	testConst := ospfv3AreaTestConst()
+	/* NSSA is signalled by clearing E-bit and setting N-bit in hello options */
+	testConst["dutAreaType"] = "nssa default-information-originate"
+	testConst["eBit"] = false
+	testConst["nBit"] = true
+	/* AS-external LSAs are blocked at NSSA boundary, where DUT instead
+	   originates a default NSSA LSA, and translates NSSA LSAs into backbone */
+	testConst["externalLsas"] = 0
+	testConst["defaultNssaLsas"] = 1
+	testConst["translatedLsas"] = 1
+
+	ospfv3AreaTest(t, testConst)
+}
+
+func ospfv3AreaTest(t *testing.T, testConst map[string]interface{}) {
+	api := otg.NewOtgApi(t)
+
+	rmDutConfig := ospfv3AreaDutConfig(api, testConst)
+	defer rmDutConfig()
+
+	c := ospfv3AreaConfig(api, testConst)
+
+	api.SetConfig(c)
+
+	api.StartProtocols()
+
+	api.WaitFor(
+		func() bool { return ospfv3AreaMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForOspfv3Metrics", Timeout: 60 * time.Second},
+	)
+
+	api.WaitFor(
+		func() bool { return ospfv3AreaLsasOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForOspfv3Lsas", Timeout: 30 * time.Second},
+	)
+
+	api.StartTransmit()
+
+	api.WaitFor(
+		func() bool { return ospfv3AreaFlowMetricsOk(api, testConst) },
+		&otg.WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+}
+
+func ospfv3AreaDutConfig(api *otg.OtgApi, tc map[string]interface{}) func() {
+	dc := &api.TestConfig().DutConfigs[0]
+
+	areaCfg := ""
+	if tc["dutAreaType"].(string) != "" {
+		areaCfg = fmt.Sprintf("area %d %s", tc["areaId"].(uint32), tc["dutAreaType"].(string))
+	}
+
+	setCfg := fmt.Sprintf(`
+		ipv6 router ospf 1
+			router-id %s
+			%s
+		!
+		interface %s
+			no switchport
+			ipv6 enable
+			ipv6 address %s/%d
+			ipv6 ospf 1 area 0
+			ipv6 ospf network point-to-point
+		!
+		interface %s
+			no switchport
+			ipv6 enable
+			ipv6 address %s/%d
+			ipv6 ospf 1 area %d
+			ipv6 ospf network point-to-point
+		!
+	`,
+		tc["dutRouterId"].(string),
+		areaCfg,
+		dc.Interfaces[0],
+		tc["txGateway"].(string),
+		tc["txPrefix"].(uint32),
+		dc.Interfaces[1],
+		tc["rxGateway"].(string),
+		tc["rxPrefix"].(uint32),
+		tc["areaId"].(uint32),
+	)
+
+	unsetCfg := fmt.Sprintf(`
+		no ipv6 router ospf 1
+		!
+		interface %s
+			no ipv6 ospf network point-to-point
+			no ipv6 address
+			no ipv6 enable
+		!
+		interface %s
+			no ipv6 ospf network point-to-point
+			no ipv6 address
+			no ipv6 enable
+		!
+	`,
+		dc.Interfaces[0],
+		dc.Interfaces[1],
+	)
+
+	return dut.NewDutApi(api.Testing(), dc).SetSshConfig(setCfg, unsetCfg)
+}
+
+func ospfv3AreaConfig(api *otg.OtgApi, tc map[string]interface{}) gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	ptx := c.Ports().Add().SetName("ptx").SetLocation(api.TestConfig().OtgPorts[0])
+	prx := c.Ports().Add().SetName("prx").SetLocation(api.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{ptx.Name(), prx.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(api.TestConfig().OtgSpeed))
+
+	dtx := c.Devices().Add().SetName("dtx")
+	drx := c.Devices().Add().SetName("drx")
+
+	// transmit
+	dtxEth := dtx.Ethernets().
+		Add().
+		SetName("dtxEth").
+		SetMac(tc["txMac"].(string)).
+		SetMtu(1500)
+
+	dtxEth.Connection().SetPortName(ptx.Name())
+
+	dtxIpV6 := dtxEth.
+		Ipv6Addresses().
+		Add().
+		SetName("dtxIpV6").
+		SetAddress(tc["txIpV6"].(string)).
+		SetGateway(tc["txGateway"].(string)).
+		SetPrefix(tc["txPrefix"].(uint32))
+
+	dtxOspfv3 := dtx.Ospfv3()
+
+	dtxOspfv3.RouterId().SetCustom(tc["txRouterId"].(string))
+
+	dtxInstanceOspfv3 := dtxOspfv3.Instances().Add().
+		SetName(tc["txRouterName"].(string)).
+		SetStoreLsa(true)
+
+	/* tx router is an AS boundary router in backbone */
+	dtxInstanceOspfv3.Capabilities().
+		SetLsaEBit(true)
+
+	dtxOspfv3Int := dtxInstanceOspfv3.
+		Interfaces().
+		Add().
+		SetName("dtxOspfv3Int").
+		SetIpv6Name(dtxIpV6.Name())
+
+	dtxOspfv3Int.Area().SetId(0)
+	dtxOspfv3Int.NetworkType().PointToPoint()
+
+	dtxOspfv3RrV6 := dtxInstanceOspfv3.
+		V6Routes().
+		Add().
+		SetName("dtxOspfv3RrV6").
+		SetMetric(tc["metric"].(uint32))
+
+	dtxOspfv3RrV6.
+		Addresses().
+		Add().
+		SetAddress(tc["txAdvRouteV6"].(string)).
+		SetPrefix(64).
+		SetCount(1).
+		SetStep(1)
+
+	dtxOspfv3RrV6.RouteOrigin().IntraArea()
+
+	dtxOspfv3RrExt := dtxInstanceOspfv3.
+		V6Routes().
+		Add().
+		SetName("dtxOspfv3RrExt").
+		SetMetric(tc["metric"].(uint32))
+
+	dtxOspfv3RrExt.
+		Addresses().
+		Add().
+		SetAddress(tc["txExtRouteV6"].(string)).
+		SetPrefix(64).
+		SetCount(1).
+		SetStep(1)
+
+	dtxOspfv3RrExt.RouteOrigin().ExternalType_2()
+
+	// recieve
+	drxEth := drx.Ethernets().
+		Add().
+		SetName("drxEth").
+		SetMac(tc["rxMac"].(string)).
+		SetMtu(1500)
+
+	drxEth.Connection().SetPortName(prx.Name())
+
+	drxIpV6 := drxEth.
+		Ipv6Addresses().
+		Add().
+		SetName("drxIpV6").
+		SetAddress(tc["rxIpV6"].(string)).
+		SetGateway(tc["rxGateway"].(string)).
+		SetPrefix(tc["rxPrefix"].(uint32))
+
+	drxOspfv3 := drx.Ospfv3()
+
+	drxOspfv3.RouterId().SetCustom(tc["rxRouterId"].(string))
+
+	drxInstanceOspfv3 := drxOspfv3.Instances().Add().
+		SetName(tc["rxRouterName"].(string)).
+		SetStoreLsa(true)
+
+	drxOspfv3Int := drxInstanceOspfv3.
+		Interfaces().
+		Add().
+		SetName("drxOspfv3Int").
+		SetIpv6Name(drxIpV6.Name())
+
+	drxOspfv3Int.Area().SetId(tc["areaId"].(uint32))
+	drxOspfv3Int.NetworkType().PointToPoint()
+	/* options need to match with DUT for adjacency to come up */
+	drxOspfv3Int.Options().
+		SetEBit(tc["eBit"].(bool)).
+		SetNBit(tc["nBit"].(bool))
+
+	drxOspfv3RrV6 := drxInstanceOspfv3.
+		V6Routes().
+		Add().
+		SetName("drxOspfv3RrV6").
+		SetMetric(tc["metric"].(uint32))
+
+	drxOspfv3RrV6.
+		Addresses().
+		Add().
+		SetAddress(tc["rxAdvRouteV6"].(string)).
+		SetPrefix(64).
+		SetCount(1).
+		SetStep(1)
+
+	drxOspfv3RrV6.RouteOrigin().IntraArea()
+
+	// traffic
+	type areaFlow struct {
+		name    string
+		txRoute gosnappi.Ospfv3V6RouteRange
+		rxRoute gosnappi.Ospfv3V6RouteRange
+		mac     string
+		src     string
+		dst     string
+	}
+	flows := []areaFlow{
+		{"ftxV6", dtxOspfv3RrV6, drxOspfv3RrV6, dtxEth.Mac(), tc["txAdvRouteV6"].(string), tc["rxAdvRouteV6"].(string)},
+		{"frxV6", drxOspfv3RrV6, dtxOspfv3RrV6, drxEth.Mac(), tc["rxAdvRouteV6"].(string), tc["txAdvRouteV6"].(string)},
+	}
+
+	if tc["nBit"].(bool) {
+		/* rx router is an AS boundary router in NSSA */
+		drxInstanceOspfv3.Capabilities().SetLsaEBit(true)
+
+		drxOspfv3RrNssa := drxInstanceOspfv3.
+			V6Routes().
+			Add().
+			SetName("drxOspfv3RrNssa").
+			SetMetric(tc["metric"].(uint32))
+
+		drxOspfv3RrNssa.
+			Addresses().
+			Add().
+			SetAddress(tc["rxNssaRouteV6"].(string)).
+			SetPrefix(64).
+			SetCount(1).
+			SetStep(1)
+
+		drxOspfv3RrNssa.RouteOrigin().NssaExternal().Capabilities().SetPropagation(true)
+
+		flows = append(flows, areaFlow{
+			"ftxV6Nssa", dtxOspfv3RrV6, drxOspfv3RrNssa, dtxEth.Mac(), tc["txAdvRouteV6"].(string), tc["rxNssaRouteV6"].(string),
+		})
+	}
+
+	for _, f := range flows {
+		flow := c.Flows().Add().SetName(f.name)
+		flow.Duration().FixedPackets().SetPackets(tc["pktCount"].(uint32))
+		flow.Rate().SetPps(tc["pktRate"].(uint64))
+		flow.Size().SetFixed(tc["pktSize"].(uint32))
+		flow.Metrics().SetEnable(true)
+
+		flow.TxRx().Device().
+			SetTxNames([]string{f.txRoute.Name()}).
+			SetRxNames([]string{f.rxRoute.Name()})
+
+		flowEth := flow.Packet().Add().Ethernet()
+		flowEth.Src().SetValue(f.mac)
+
+		flowIp := flow.Packet().Add().Ipv6()
+		flowIp.Src().SetValue(f.src)
+		flowIp.Dst().SetValue(f.dst)
+
+		flowTcp := flow.Packet().Add().Tcp()
+		flowTcp.SrcPort().SetValue(5000)
+		flowTcp.DstPort().SetValue(6000)
+	}
+
+	api.Testing().Logf("Config:\n%v\n", c)
+	return c
+}
+
+func ospfv3AreaMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	/* LSAs filtered at area boundary must not be received at all */
+	countOk := func(act uint64, exp int) bool {
+		if exp == 0 {
+			return act == 0
+		}
+		return act >= uint64(exp)
+	}
+
+	count := 0
+	for _, m := range api.GetOspfv3Metrics() {
+		switch m.Name() {
+		case tc["txRouterName"].(string):
+			/* NSSA LSAs never leave NSSA */
+			if m.FullStateCount() < 1 ||
+				m.InterAreaPrefixLsaReceived() < 1 ||
+				m.ExternalLsaReceived() < uint64(tc["translatedLsas"].(int)) ||
+				m.NssaLsaReceived() != 0 {
+				return false
+			}
+		case tc["rxRouterName"].(string):
+			if m.FullStateCount() < 1 ||
+				m.InterAreaPrefixLsaReceived() < 1 ||
+				!countOk(m.ExternalLsaReceived(), tc["externalLsas"].(int)) ||
+				!countOk(m.NssaLsaReceived(), tc["defaultNssaLsas"].(int)) {
+				return false
+			}
+		default:
+			continue
+		}
+		count += 1
+	}
+	return count == 2
+}
+
+func ospfv3AreaLsasOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	t := api.Testing()
+	lsas := otg.FlattenOspfv3Lsas(api.GetOspfv3Lsas())
+
+	txRouterName := tc["txRouterName"].(string)
+	rxRouterName := tc["rxRouterName"].(string)
+	dutRouterId := tc["dutRouterId"].(string)
+
+	for _, e := range []struct {
+		match otg.OspfLsaMatch
+		count int
+	}{
+		/* inter-area prefixes generated by DUT for intra-area routes of each area */
+		{otg.OspfLsaMatch{
+			RouterName:          rxRouterName,
+			Type:                otg.OspfLsaInterAreaPrefix,
+			AdvertisingRouterId: dutRouterId,
+			Prefix:              tc["txAdvRouteV6"].(string) + "/64",
+		}, 1},
+		{otg.OspfLsaMatch{
+			RouterName:          txRouterName,
+			Type:                otg.OspfLsaInterAreaPrefix,
+			AdvertisingRouterId: dutRouterId,
+			Prefix:              tc["rxAdvRouteV6"].(string) + "/64",
+		}, 1},
+		/* external LSAs are flooded unchanged, unless blocked at area boundary */
+		{otg.OspfLsaMatch{RouterName: rxRouterName, Type: otg.OspfLsaExternalAs}, tc["externalLsas"].(int)},
+		{otg.OspfLsaMatch{
+			RouterName:          rxRouterName,
+			Type:                otg.OspfLsaExternalAs,
+			AdvertisingRouterId: tc["txRouterId"].(string),
+			Prefix:              tc["txExtRouteV6"].(string) + "/64",
+			Metric:              tc["metric"].(uint32),
+		}, tc["externalLsas"].(int)},
+		/* default route replacing external routes in stub area or NSSA */
+		{otg.OspfLsaMatch{
+			RouterName:          rxRouterName,
+			Type:                otg.OspfLsaInterAreaPrefix,
+			AdvertisingRouterId: dutRouterId,
+			Prefix:              "::/0",
+		}, tc["defaultInterAreaPrefixLsas"].(int)},
+		{otg.OspfLsaMatch{
+			RouterName:          rxRouterName,
+			Type:                otg.OspfLsaNssa,
+			AdvertisingRouterId: dutRouterId,
+			Prefix:              "::/0",
+		}, tc["defaultNssaLsas"].(int)},
+		/* NSSA LSA of rx router translated by DUT into backbone */
+		{otg.OspfLsaMatch{RouterName: txRouterName, Type: otg.OspfLsaNssa}, 0},
+		{otg.OspfLsaMatch{
+			RouterName:          txRouterName,
+			Type:                otg.OspfLsaExternalAs,
+			AdvertisingRouterId: dutRouterId,
+			Prefix:              tc["rxNssaRouteV6"].(string) + "/64",
+		}, tc["translatedLsas"].(int)},
+	} {
+		if !e.match.Has(t, lsas, e.count) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func ospfv3AreaFlowMetricsOk(api *otg.OtgApi, tc map[string]interface{}) bool {
+	pktCount := uint64(tc["pktCount"].(uint32))
+
+	for _, m := range api.GetFlowMetrics() {
+		if m.Transmit() != gosnappi.FlowMetricTransmit.STOPPED ||
+			m.FramesTx() != pktCount ||
+			m.FramesRx() != pktCount {
+			return false
+		}
+	}
+
+	return true
+}
@@ -98,6 +98,112 @@ func (o *OtgApi) StopProtocols() {
 	o.LogWrnErr(res, err, true)
 }
 
+func (o *OtgApi) StartBgpPeers(peerNames []string) {
+	o.Testing().Logf("Starting BGP peers %v ...\n", peerNames)
+	defer o.Timer(time.Now(), "StartBgpPeers")
+
+	cs := gosnappi.NewControlState()
+	cs.Protocol().Bgp().Peers().
+		SetPeerNames(peerNames).
+		SetState(gosnappi.StateProtocolBgpPeersState.UP)
+	res, err := o.Api().SetControlState(cs)
+	o.LogWrnErr(res, err, true)
+}
+
+func (o *OtgApi) StopBgpPeers(peerNames []string) {
+	o.Testing().Logf("Stopping BGP peers %v ...\n", peerNames)
+	defer o.Timer(time.Now(), "StopBgpPeers")
+
+	cs := gosnappi.NewControlState()
+	cs.Protocol().Bgp().Peers().
+		SetPeerNames(peerNames).
+		SetState(gosnappi.StateProtocolBgpPeersState.DOWN)
+	res, err := o.Api().SetControlState(cs)
+	o.LogWrnErr(res, err, true)
+}
+
+func (o *OtgApi) StartLacpMemberPorts(memberNames []string) {
+	o.Testing().Logf("Starting LACP on LAG member ports %v ...\n", memberNames)
+	defer o.Timer(time.Now(), "StartLacpMemberPorts")
+
+	cs := gosnappi.NewControlState()
+	cs.Protocol().Lacp().MemberPorts().
+		SetLagMemberNames(memberNames).
+		SetState(gosnappi.StateProtocolLacpMemberPortsState.UP)
+	res, err := o.Api().SetControlState(cs)
+	o.LogWrnErr(res, err, true)
+}
+
+func (o *OtgApi) StopLacpMemberPorts(memberNames []string) {
+	o.Testing().Logf("Stopping LACP on LAG member ports %v ...\n", memberNames)
+	defer o.Timer(time.Now(), "StopLacpMemberPorts")
+
+	cs := gosnappi.NewControlState()
+	cs.Protocol().Lacp().MemberPorts().
+		SetLagMemberNames(memberNames).
+		SetState(gosnappi.StateProtocolLacpMemberPortsState.DOWN)
+	res, err := o.Api().SetControlState(cs)
+	o.LogWrnErr(res, err, true)
+}
+
+func (o *OtgApi) SetPortLinksUp(portNames []string) {
+	o.Testing().Logf("Setting links up on ports %v ...\n", portNames)
+	defer o.Timer(time.Now(), "SetPortLinksUp")
+
+	cs := gosnappi.NewControlState()
+	cs.Port().Link().
+		SetPortNames(portNames).
+		SetState(gosnappi.StatePortLinkState.UP)
+	res, err := o.Api().SetControlState(cs)
+	o.LogWrnErr(res, err, true)
+}
+
+func (o *OtgApi) SetPortLinksDown(portNames []string) {
+	o.Testing().Logf("Setting links down on ports %v ...\n", portNames)
+	defer o.Timer(time.Now(), "SetPortLinksDown")
+
+	cs := gosnappi.NewControlState()
+	cs.Port().Link().
+		SetPortNames(portNames).
+		SetState(gosnappi.StatePortLinkState.DOWN)
+	res, err := o.Api().SetControlState(cs)
+	o.LogWrnErr(res, err, true)
+}
+
+// PortLinksOk returns true if link of each port in links is reported in
+// expected state by port metrics
+func (o *OtgApi) PortLinksOk(links map[string]gosnappi.PortMetricLinkEnum) bool {
+	count := 0
+	for _, m := range o.GetPortMetrics() {
+		if l, ok := links[m.Name()]; ok {
+			if m.Link() != l {
+				return false
+			}
+			count += 1
+		}
+	}
+
+	return count == len(links)
+}
+
+// FlowFramesRxOver returns frames received by each flow over window, keyed
+// by flow name
+func (o *OtgApi) FlowFramesRxOver(window time.Duration) map[string]uint64 {
+	before := map[string]uint64{}
+	for _, m := range o.GetFlowMetrics() {
+		before[m.Name()] = m.FramesRx()
+	}
+
+	time.Sleep(window)
+
+	frames := map[string]uint64{}
+	for _, m := range o.GetFlowMetrics() {
+		frames[m.Name()] = m.FramesRx() - before[m.Name()]
+	}
+
+	return frames
+}
+
 func (o *OtgApi) StartTransmit() {
 	o.Testing().Log("Starting transmit ...")
 	defer o.Timer(time.Now(), "StartTransmit")
@@ -118,6 +224,54 @@ func (o *OtgApi) StopTransmit() {
 	o.LogWrnErr(res, err, true)
 }
 
+func (o *OtgApi) StartFlows(flowNames []string) {
+	o.Testing().Logf("Starting transmit on flows %v ...\n", flowNames)
+	defer o.Timer(time.Now(), "StartFlows")
+
+	cs := gosnappi.NewControlState()
+	cs.Traffic().FlowTransmit().
+		SetFlowNames(flowNames).
+		SetState(gosnappi.StateTrafficFlowTransmitState.START)
+	res, err := o.Api().SetControlState(cs)
+	o.LogWrnErr(res, err, true)
+}
+
+func (o *OtgApi) StopFlows(flowNames []string) {
+	o.Testing().Logf("Stopping transmit on flows %v ...\n", flowNames)
+	defer o.Timer(time.Now(), "StopFlows")
+
+	cs := gosnappi.NewControlState()
+	cs.Traffic().FlowTransmit().
+		SetFlowNames(flowNames).
+		SetState(gosnappi.StateTrafficFlowTransmitState.STOP)
+	res, err := o.Api().SetControlState(cs)
+	o.LogWrnErr(res, err, true)
+}
+
+func (o *OtgApi) PauseFlows(flowNames []string) {
+	o.Testing().Logf("Pausing transmit on flows %v ...\n", flowNames)
+	defer o.Timer(time.Now(), "PauseFlows")
+
+	cs := gosnappi.NewControlState()
+	cs.Traffic().FlowTransmit().
+		SetFlowNames(flowNames).
+		SetState(gosnappi.StateTrafficFlowTransmitState.PAUSE)
+	res, err := o.Api().SetControlState(cs)
+	o.LogWrnErr(res, err, true)
+}
+
+func (o *OtgApi) ResumeFlows(flowNames []string) {
+	o.Testing().Logf("Resuming transmit on flows %v ...\n", flowNames)
+	defer o.Timer(time.Now(), "ResumeFlows")
+
+	cs := gosnappi.NewControlState()
+	cs.Traffic().FlowTransmit().
+		SetFlowNames(flowNames).
+		SetState(gosnappi.StateTrafficFlowTransmitState.RESUME)
+	res, err := o.Api().SetControlState(cs)
+	o.LogWrnErr(res, err, true)
+}
+
 func (o *OtgApi) StartCapture() {
 	if !o.TestConfig().OtgCaptureCheck {
 		o.Testing().Log("Skipped StartCapture")
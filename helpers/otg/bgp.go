@@ -0,0 +1,502 @@
+package otg
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+// approximate prefix length distribution of the public IPv4 internet table
+var BgpInternetV4PrefixLengths = map[uint32]int{
+	16: 2, 17: 1, 18: 2, 19: 4, 20: 6, 21: 7, 22: 12, 23: 10, 24: 56,
+}
+
+// approximate prefix length distribution of the public IPv6 internet table
+var BgpInternetV6PrefixLengths = map[uint32]int{
+	29: 3, 32: 12, 36: 4, 40: 6, 44: 8, 46: 2, 47: 2, 48: 63,
+}
+
+type BgpCommunityValue struct {
+	AsNumber uint32
+	AsCustom uint32
+}
+
+func (c BgpCommunityValue) String() string {
+	return fmt.Sprintf("%d:%d", c.AsNumber, c.AsCustom)
+}
+
+type BgpRouteScaleSpec struct {
+	// prefix used for names of generated route ranges
+	Name string
+	// first prefix to be advertised, either IPv4 or IPv6
+	StartAddress   string
+	RangeCount     int
+	RoutesPerRange uint32
+	// prefix length to relative weight
+	PrefixLengths map[uint32]int
+	// manual next hop of all route ranges; local IP is used when empty
+	NextHop string
+	// local AS is prepended to generated AS paths when non-zero
+	LocalAs      uint32
+	AsPathMinLen int
+	AsPathMaxLen int
+	AsPathPool   []uint32
+	// each route range carries 0 to MaxCommunities communities from pool
+	CommunityPool  []BgpCommunityValue
+	MaxCommunities int
+	MedMin         uint32
+	MedMax         uint32
+	// local preference is not advertised when empty
+	LocalPrefs []uint32
+	Seed       int64
+}
+
+type BgpExpectedPrefix struct {
+	Address      string
+	PrefixLength uint32
+	NextHop      string
+	AsPath       []uint32
+	Communities  []BgpCommunityValue
+	Med          uint32
+	LocalPref    uint32
+}
+
+func (p *BgpExpectedPrefix) Key() string {
+	return bgpPrefixKey(p.Address, p.PrefixLength)
+}
+
+func bgpPrefixKey(address string, prefixLength uint32) string {
+	if ip := net.ParseIP(address); ip != nil {
+		address = ip.String()
+	}
+	return fmt.Sprintf("%s/%d", address, prefixLength)
+}
+
+type bgpScaleRange struct {
+	name         string
+	address      string
+	prefixLength uint32
+	count        uint32
+	asPath       []uint32
+	communities  []BgpCommunityValue
+	med          uint32
+	localPref    uint32
+}
+
+type BgpRouteScale struct {
+	spec     BgpRouteScaleSpec
+	isV6     bool
+	ranges   []bgpScaleRange
+	expected map[string]BgpExpectedPrefix
+}
+
+func (o *OtgApi) NewBgpRouteScale(spec BgpRouteScaleSpec) *BgpRouteScale {
+	t := o.Testing()
+
+	ip := net.ParseIP(spec.StartAddress)
+	if ip == nil {
+		t.Fatalf("ERROR: Could not parse start address %s\n", spec.StartAddress)
+	}
+	isV6 := ip.To4() == nil
+	bits := uint32(32)
+	if isV6 {
+		bits = 128
+	} else {
+		ip = ip.To4()
+	}
+
+	if spec.RangeCount <= 0 || spec.RoutesPerRange == 0 {
+		t.Fatalf("ERROR: RangeCount %d and RoutesPerRange %d must be positive\n", spec.RangeCount, spec.RoutesPerRange)
+	}
+	if len(spec.PrefixLengths) == 0 {
+		t.Fatalf("ERROR: PrefixLengths must not be empty\n")
+	}
+	for l, w := range spec.PrefixLengths {
+		if l == 0 || l > bits || w < 0 {
+			t.Fatalf("ERROR: Invalid prefix length %d with weight %d\n", l, w)
+		}
+	}
+	if spec.AsPathMaxLen < spec.AsPathMinLen || (spec.AsPathMaxLen > 0 && len(spec.AsPathPool) == 0) {
+		t.Fatalf("ERROR: Invalid AS path length range [%d, %d] for pool %v\n", spec.AsPathMinLen, spec.AsPathMaxLen, spec.AsPathPool)
+	}
+	if spec.MedMax < spec.MedMin {
+		t.Fatalf("ERROR: MedMax %d < MedMin %d\n", spec.MedMax, spec.MedMin)
+	}
+	if spec.MaxCommunities > len(spec.CommunityPool) {
+		t.Fatalf("ERROR: MaxCommunities %d > len(CommunityPool) %d\n", spec.MaxCommunities, len(spec.CommunityPool))
+	}
+	if spec.Name == "" {
+		spec.Name = "scale"
+	}
+
+	s := &BgpRouteScale{
+		spec:     spec,
+		isV6:     isV6,
+		ranges:   make([]bgpScaleRange, 0, spec.RangeCount),
+		expected: map[string]BgpExpectedPrefix{},
+	}
+
+	r := rand.New(rand.NewSource(spec.Seed))
+	lengths, weights := bgpSortedWeights(spec.PrefixLengths)
+	cursor := new(big.Int).SetBytes(ip)
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+
+	for i := 0; i < spec.RangeCount; i++ {
+		prefixLength := bgpWeightedChoice(r, lengths, weights)
+		blockSize := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLength))
+
+		// align cursor to the prefix boundary so that advertised prefixes never overlap
+		rem := new(big.Int).Mod(cursor, blockSize)
+		if rem.Sign() != 0 {
+			cursor.Add(cursor, new(big.Int).Sub(blockSize, rem))
+		}
+
+		end := new(big.Int).Mul(blockSize, big.NewInt(int64(spec.RoutesPerRange)))
+		if end.Add(end, cursor).Cmp(limit) > 0 {
+			t.Fatalf("ERROR: Address space exhausted while generating route range %s%d\n", spec.Name, i)
+		}
+
+		rg := bgpScaleRange{
+			name:         fmt.Sprintf("%s%d", spec.Name, i),
+			address:      bgpIntToIp(cursor, isV6),
+			prefixLength: prefixLength,
+			count:        spec.RoutesPerRange,
+		}
+
+		if spec.AsPathMaxLen > 0 {
+			n := spec.AsPathMinLen + r.Intn(spec.AsPathMaxLen-spec.AsPathMinLen+1)
+			for j := 0; j < n; j++ {
+				rg.asPath = append(rg.asPath, spec.AsPathPool[r.Intn(len(spec.AsPathPool))])
+			}
+		}
+
+		if spec.MaxCommunities > 0 {
+			for _, k := range r.Perm(len(spec.CommunityPool))[:r.Intn(spec.MaxCommunities+1)] {
+				rg.communities = append(rg.communities, spec.CommunityPool[k])
+			}
+		}
+
+		rg.med = spec.MedMin
+		if spec.MedMax > spec.MedMin {
+			rg.med += uint32(r.Int63n(int64(spec.MedMax-spec.MedMin) + 1))
+		}
+
+		if len(spec.LocalPrefs) > 0 {
+			rg.localPref = spec.LocalPrefs[r.Intn(len(spec.LocalPrefs))]
+		}
+
+		for j := uint32(0); j < rg.count; j++ {
+			p := BgpExpectedPrefix{
+				Address:      bgpIntToIp(cursor, isV6),
+				PrefixLength: prefixLength,
+				NextHop:      spec.NextHop,
+				Communities:  rg.communities,
+				Med:          rg.med,
+				LocalPref:    rg.localPref,
+			}
+			if spec.LocalAs != 0 {
+				p.AsPath = append([]uint32{spec.LocalAs}, rg.asPath...)
+			} else {
+				p.AsPath = rg.asPath
+			}
+			s.expected[p.Key()] = p
+			cursor.Add(cursor, blockSize)
+		}
+
+		s.ranges = append(s.ranges, rg)
+	}
+
+	return s
+}
+
+func bgpSortedWeights(m map[uint32]int) ([]uint32, []int) {
+	keys := make([]uint32, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	weights := make([]int, 0, len(keys))
+	for _, k := range keys {
+		weights = append(weights, m[k])
+	}
+	return keys, weights
+}
+
+func bgpWeightedChoice(r *rand.Rand, keys []uint32, weights []int) uint32 {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		return keys[r.Intn(len(keys))]
+	}
+
+	n := r.Intn(total)
+	for i, w := range weights {
+		if n < w {
+			return keys[i]
+		}
+		n -= w
+	}
+	return keys[len(keys)-1]
+}
+
+func bgpIntToIp(v *big.Int, isV6 bool) string {
+	size := 4
+	if isV6 {
+		size = 16
+	}
+	b := v.Bytes()
+	ip := make(net.IP, size)
+	copy(ip[size-len(b):], b)
+	return ip.String()
+}
+
+func (s *BgpRouteScale) IsIpv6() bool {
+	return s.isV6
+}
+
+func (s *BgpRouteScale) RouteCount() uint64 {
+	return uint64(len(s.expected))
+}
+
+func (s *BgpRouteScale) ExpectedPrefixes() map[string]BgpExpectedPrefix {
+	return s.expected
+}
+
+func (s *BgpRouteScale) AddToV4Peer(peer gosnappi.BgpV4Peer) {
+	for _, rg := range s.ranges {
+		if s.isV6 {
+			s.setV6RouteRange(peer.V6Routes().Add(), rg)
+		} else {
+			s.setV4RouteRange(peer.V4Routes().Add(), rg)
+		}
+	}
+}
+
+func (s *BgpRouteScale) AddToV6Peer(peer gosnappi.BgpV6Peer) {
+	for _, rg := range s.ranges {
+		if s.isV6 {
+			s.setV6RouteRange(peer.V6Routes().Add(), rg)
+		} else {
+			s.setV4RouteRange(peer.V4Routes().Add(), rg)
+		}
+	}
+}
+
+func (s *BgpRouteScale) asPathSetMode() gosnappi.BgpAsPathAsSetModeEnum {
+	if s.spec.LocalAs != 0 {
+		return gosnappi.BgpAsPathAsSetMode.INCLUDE_AS_SEQ
+	}
+	return gosnappi.BgpAsPathAsSetMode.DO_NOT_INCLUDE_LOCAL_AS
+}
+
+func (s *BgpRouteScale) setV4RouteRange(rr gosnappi.BgpV4RouteRange, rg bgpScaleRange) {
+	rr.SetName(rg.name)
+	if s.spec.NextHop != "" {
+		rr.SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+		if net.ParseIP(s.spec.NextHop).To4() == nil {
+			rr.SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV6).
+				SetNextHopIpv6Address(s.spec.NextHop)
+		} else {
+			rr.SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+				SetNextHopIpv4Address(s.spec.NextHop)
+		}
+	}
+
+	rr.Addresses().Add().
+		SetAddress(rg.address).
+		SetPrefix(rg.prefixLength).
+		SetCount(rg.count).
+		SetStep(1)
+
+	adv := rr.Advanced().
+		SetMultiExitDiscriminator(rg.med).
+		SetOrigin(gosnappi.BgpRouteAdvancedOrigin.IGP)
+	if rg.localPref != 0 {
+		adv.SetIncludeLocalPreference(true).SetLocalPreference(rg.localPref)
+	} else {
+		adv.SetIncludeLocalPreference(false)
+	}
+
+	for _, c := range rg.communities {
+		rr.Communities().Add().
+			SetAsNumber(c.AsNumber).
+			SetAsCustom(c.AsCustom).
+			SetType(gosnappi.BgpCommunityType.MANUAL_AS_NUMBER)
+	}
+
+	asPath := rr.AsPath().SetAsSetMode(s.asPathSetMode())
+	if len(rg.asPath) > 0 {
+		asPath.Segments().Add().
+			SetAsNumbers(rg.asPath).
+			SetType(gosnappi.BgpAsPathSegmentType.AS_SEQ)
+	}
+}
+
+func (s *BgpRouteScale) setV6RouteRange(rr gosnappi.BgpV6RouteRange, rg bgpScaleRange) {
+	rr.SetName(rg.name)
+	if s.spec.NextHop != "" {
+		rr.SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.MANUAL).
+			SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV6).
+			SetNextHopIpv6Address(s.spec.NextHop)
+	}
+
+	rr.Addresses().Add().
+		SetAddress(rg.address).
+		SetPrefix(rg.prefixLength).
+		SetCount(rg.count).
+		SetStep(1)
+
+	adv := rr.Advanced().
+		SetMultiExitDiscriminator(rg.med).
+		SetOrigin(gosnappi.BgpRouteAdvancedOrigin.IGP)
+	if rg.localPref != 0 {
+		adv.SetIncludeLocalPreference(true).SetLocalPreference(rg.localPref)
+	} else {
+		adv.SetIncludeLocalPreference(false)
+	}
+
+	for _, c := range rg.communities {
+		rr.Communities().Add().
+			SetAsNumber(c.AsNumber).
+			SetAsCustom(c.AsCustom).
+			SetType(gosnappi.BgpCommunityType.MANUAL_AS_NUMBER)
+	}
+
+	asPath := rr.AsPath().SetAsSetMode(s.asPathSetMode())
+	if len(rg.asPath) > 0 {
+		asPath.Segments().Add().
+			SetAsNumbers(rg.asPath).
+			SetType(gosnappi.BgpAsPathSegmentType.AS_SEQ)
+	}
+}
+
+func bgpResultAsPath(asPath gosnappi.ResultBgpAsPath) []uint32 {
+	out := []uint32{}
+	for _, s := range asPath.Segments().Items() {
+		out = append(out, s.AsNumbers()...)
+	}
+	return out
+}
+
+func bgpResultCommunities(communities []gosnappi.ResultBgpCommunity) []string {
+	out := []string{}
+	for _, c := range communities {
+		out = append(out, BgpCommunityValue{AsNumber: c.AsNumber(), AsCustom: c.AsCustom()}.String())
+	}
+	sort.Strings(out)
+	return out
+}
+
+func bgpExpectedCommunities(communities []BgpCommunityValue) []string {
+	out := []string{}
+	for _, c := range communities {
+		out = append(out, c.String())
+	}
+	sort.Strings(out)
+	return out
+}
+
+type bgpReceivedPrefix struct {
+	nextHop     string
+	asPath      []uint32
+	communities []string
+	hasMed      bool
+	med         uint32
+	hasLp       bool
+	localPref   uint32
+}
+
+func (s *BgpRouteScale) receivedPrefixes(states []gosnappi.BgpPrefixesState, peerName string) map[string]bgpReceivedPrefix {
+	recv := map[string]bgpReceivedPrefix{}
+	for _, m := range states {
+		if m.BgpPeerName() != peerName {
+			continue
+		}
+		if s.isV6 {
+			for _, p := range m.Ipv6UnicastPrefixes().Items() {
+				recv[bgpPrefixKey(p.Ipv6Address(), p.PrefixLength())] = bgpReceivedPrefix{
+					nextHop:     p.Ipv6NextHop(),
+					asPath:      bgpResultAsPath(p.AsPath()),
+					communities: bgpResultCommunities(p.Communities().Items()),
+					hasMed:      p.HasMultiExitDiscriminator(),
+					med:         p.MultiExitDiscriminator(),
+					hasLp:       p.HasLocalPreference(),
+					localPref:   p.LocalPreference(),
+				}
+			}
+		} else {
+			for _, p := range m.Ipv4UnicastPrefixes().Items() {
+				nextHop := p.Ipv4NextHop()
+				if !p.HasIpv4NextHop() {
+					nextHop = p.Ipv6NextHop()
+				}
+				recv[bgpPrefixKey(p.Ipv4Address(), p.PrefixLength())] = bgpReceivedPrefix{
+					nextHop:     nextHop,
+					asPath:      bgpResultAsPath(p.AsPath()),
+					communities: bgpResultCommunities(p.Communities().Items()),
+					hasMed:      p.HasMultiExitDiscriminator(),
+					med:         p.MultiExitDiscriminator(),
+					hasLp:       p.HasLocalPreference(),
+					localPref:   p.LocalPreference(),
+				}
+			}
+		}
+	}
+	return recv
+}
+
+// CheckPrefixes ensures that prefixes learnt by given peer are exactly the
+// generated ones, carrying the generated attributes
+func (s *BgpRouteScale) CheckPrefixes(states []gosnappi.BgpPrefixesState, peerName string) error {
+	recv := s.receivedPrefixes(states, peerName)
+	if len(recv) != len(s.expected) {
+		return fmt.Errorf("peer %s: expCount %d != actCount %d", peerName, len(s.expected), len(recv))
+	}
+
+	for key, exp := range s.expected {
+		act, ok := recv[key]
+		if !ok {
+			return fmt.Errorf("peer %s: prefix %s not received", peerName, key)
+		}
+		if exp.NextHop != "" && !net.ParseIP(exp.NextHop).Equal(net.ParseIP(act.nextHop)) {
+			return fmt.Errorf("peer %s: prefix %s: expNextHop %s != actNextHop %s", peerName, key, exp.NextHop, act.nextHop)
+		}
+		if fmt.Sprint(exp.AsPath) != fmt.Sprint(act.asPath) {
+			return fmt.Errorf("peer %s: prefix %s: expAsPath %v != actAsPath %v", peerName, key, exp.AsPath, act.asPath)
+		}
+		if expC := bgpExpectedCommunities(exp.Communities); fmt.Sprint(expC) != fmt.Sprint(act.communities) {
+			return fmt.Errorf("peer %s: prefix %s: expCommunities %v != actCommunities %v", peerName, key, expC, act.communities)
+		}
+		if !act.hasMed || exp.Med != act.med {
+			return fmt.Errorf("peer %s: prefix %s: expMed %d != actMed %d", peerName, key, exp.Med, act.med)
+		}
+		if exp.LocalPref != 0 && (!act.hasLp || exp.LocalPref != act.localPref) {
+			return fmt.Errorf("peer %s: prefix %s: expLocalPref %d != actLocalPref %d", peerName, key, exp.LocalPref, act.localPref)
+		}
+	}
+
+	return nil
+}
+
+func (s *BgpRouteScale) HasPrefixes(t *testing.T, states []gosnappi.BgpPrefixesState, peerName string) bool {
+	if err := s.CheckPrefixes(states, peerName); err != nil {
+		t.Logf("WARNING: %v\n", err)
+		return false
+	}
+
+	return true
+}
+
+func (s *BgpRouteScale) ValidatePrefixes(t *testing.T, states []gosnappi.BgpPrefixesState, peerName string) {
+	if err := s.CheckPrefixes(states, peerName); err != nil {
+		t.Fatalf("ERROR: %v\n", err)
+	}
+}
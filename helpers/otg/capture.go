@@ -81,6 +81,175 @@ func (c *CapturedPackets) HasField(t *testing.T, name string, sequence int, star
 	return true
 }
 
+// internetChecksum returns checksum (RFC 1071) of data
+func internetChecksum(data []byte) uint16 {
+	sum := uint32(0)
+	for i := 0; i < len(data); i += 2 {
+		if i+1 < len(data) {
+			sum += uint32(data[i])<<8 | uint32(data[i+1])
+		} else {
+			sum += uint32(data[i]) << 8
+		}
+	}
+	for sum > 0xffff {
+		sum = sum>>16 + sum&0xffff
+	}
+
+	return ^uint16(sum)
+}
+
+// ipv4HeaderChecksum returns checksum of IPv4 header at ipOffset of data
+func ipv4HeaderChecksum(data []byte, ipOffset int) (uint16, error) {
+	if ipOffset < 0 || ipOffset+20 > len(data) {
+		return 0, fmt.Errorf("ipv4 header at %d exceeds data of length %d", ipOffset, len(data))
+	}
+	end := ipOffset + int(data[ipOffset]&0x0f)*4
+	if end < ipOffset+20 || end > len(data) {
+		return 0, fmt.Errorf("ipv4 header at %d has invalid header length %d", ipOffset, data[ipOffset]&0x0f)
+	}
+
+	b := append([]byte{}, data[ipOffset:end]...)
+	b[10], b[11] = 0, 0
+	return internetChecksum(b), nil
+}
+
+// upperLayerChecksum returns checksum of upper layer header at offset of data
+// till end of IP packet at ipOffset, where checksum itself is at byte offset
+// at; pseudo header is included unless protocol is 0, and UDP checksum of 0
+// over IPv4 is returned as is, since it means no checksum was computed
+func upperLayerChecksum(data []byte, ipOffset int, offset int, at int, protocol uint8) (uint16, error) {
+	if ipOffset < 0 || ipOffset+1 > len(data) {
+		return 0, fmt.Errorf("ip header at %d exceeds data of length %d", ipOffset, len(data))
+	}
+
+	var pseudo []byte
+	end := 0
+	switch data[ipOffset] >> 4 {
+	case 4:
+		if ipOffset+20 > len(data) {
+			return 0, fmt.Errorf("ipv4 header at %d exceeds data of length %d", ipOffset, len(data))
+		}
+		end = ipOffset + int(binary.BigEndian.Uint16(data[ipOffset+2:]))
+		pseudo = make([]byte, 12)
+		copy(pseudo, data[ipOffset+12:ipOffset+20])
+		pseudo[9] = protocol
+		binary.BigEndian.PutUint16(pseudo[10:], uint16(end-offset))
+	case 6:
+		if ipOffset+40 > len(data) {
+			return 0, fmt.Errorf("ipv6 header at %d exceeds data of length %d", ipOffset, len(data))
+		}
+		end = ipOffset + 40 + int(binary.BigEndian.Uint16(data[ipOffset+4:]))
+		pseudo = make([]byte, 40)
+		copy(pseudo, data[ipOffset+8:ipOffset+40])
+		binary.BigEndian.PutUint32(pseudo[32:], uint32(end-offset))
+		pseudo[39] = protocol
+	default:
+		return 0, fmt.Errorf("ip header at %d has invalid version %d", ipOffset, data[ipOffset]>>4)
+	}
+
+	if offset > at || at+2 > end || end > len(data) {
+		return 0, fmt.Errorf("checksum at %d not in range [%d, %d) of data of length %d", at, offset, end, len(data))
+	}
+	if protocol == 0 {
+		pseudo = nil
+	}
+	if protocol == 17 && data[ipOffset]>>4 == 4 && binary.BigEndian.Uint16(data[at:]) == 0 {
+		return 0, nil
+	}
+
+	b := append(pseudo, data[offset:end]...)
+	b[len(pseudo)+at-offset] = 0
+	b[len(pseudo)+at-offset+1] = 0
+	sum := internetChecksum(b)
+	// computed udp checksum of 0 is sent as all ones
+	if sum == 0 && protocol == 17 {
+		sum = 0xffff
+	}
+
+	return sum, nil
+}
+
+func (c *CapturedPackets) checkChecksum(name string, sequence int, at int, checksum func([]byte) (uint16, error)) error {
+	if sequence >= len(c.Packets) {
+		return fmt.Errorf("sequence %d >= len(capturedPackets) %d", sequence, len(c.Packets))
+	}
+
+	data := c.Packets[sequence].Data
+	exp, err := checksum(data)
+	if err != nil {
+		return fmt.Errorf("%s: %v; sequence: %d", name, err, sequence)
+	}
+	act := binary.BigEndian.Uint16(data[at:])
+	if exp != act {
+		return fmt.Errorf("%s: computed 0x%04x != actual 0x%04x; sequence: %d, data: %v", name, exp, act, sequence, data)
+	}
+
+	return nil
+}
+
+// CheckIpv4Checksum recomputes header checksum of IPv4 header at ipOffset and
+// compares it with the one in packet
+func (c *CapturedPackets) CheckIpv4Checksum(sequence int, ipOffset int) error {
+	return c.checkChecksum("ipv4 checksum", sequence, ipOffset+10, func(data []byte) (uint16, error) {
+		return ipv4HeaderChecksum(data, ipOffset)
+	})
+}
+
+// CheckUdpChecksum recomputes checksum of UDP header at udpOffset, including
+// pseudo header of IPv4 or IPv6 header at ipOffset, and compares it with the
+// one in packet
+func (c *CapturedPackets) CheckUdpChecksum(sequence int, ipOffset int, udpOffset int) error {
+	return c.checkChecksum("udp checksum", sequence, udpOffset+6, func(data []byte) (uint16, error) {
+		return upperLayerChecksum(data, ipOffset, udpOffset, udpOffset+6, 17)
+	})
+}
+
+// CheckTcpChecksum recomputes checksum of TCP header at tcpOffset, including
+// pseudo header of IPv4 or IPv6 header at ipOffset, and compares it with the
+// one in packet
+func (c *CapturedPackets) CheckTcpChecksum(sequence int, ipOffset int, tcpOffset int) error {
+	return c.checkChecksum("tcp checksum", sequence, tcpOffset+16, func(data []byte) (uint16, error) {
+		return upperLayerChecksum(data, ipOffset, tcpOffset, tcpOffset+16, 6)
+	})
+}
+
+// CheckIcmpChecksum recomputes checksum of ICMP header at icmpOffset, or that
+// of ICMPv6 header including pseudo header if IP header at ipOffset is IPv6,
+// and compares it with the one in packet
+func (c *CapturedPackets) CheckIcmpChecksum(sequence int, ipOffset int, icmpOffset int) error {
+	return c.checkChecksum("icmp checksum", sequence, icmpOffset+2, func(data []byte) (uint16, error) {
+		protocol := uint8(0)
+		if ipOffset >= 0 && ipOffset < len(data) && data[ipOffset]>>4 == 6 {
+			protocol = 58
+		}
+		return upperLayerChecksum(data, ipOffset, icmpOffset, icmpOffset+2, protocol)
+	})
+}
+
+func (c *CapturedPackets) ValidateIpv4Checksum(t *testing.T, sequence int, ipOffset int) {
+	if err := c.CheckIpv4Checksum(sequence, ipOffset); err != nil {
+		t.Fatalf("ERROR: %v\n", err)
+	}
+}
+
+func (c *CapturedPackets) ValidateUdpChecksum(t *testing.T, sequence int, ipOffset int, udpOffset int) {
+	if err := c.CheckUdpChecksum(sequence, ipOffset, udpOffset); err != nil {
+		t.Fatalf("ERROR: %v\n", err)
+	}
+}
+
+func (c *CapturedPackets) ValidateTcpChecksum(t *testing.T, sequence int, ipOffset int, tcpOffset int) {
+	if err := c.CheckTcpChecksum(sequence, ipOffset, tcpOffset); err != nil {
+		t.Fatalf("ERROR: %v\n", err)
+	}
+}
+
+func (c *CapturedPackets) ValidateIcmpChecksum(t *testing.T, sequence int, ipOffset int, icmpOffset int) {
+	if err := c.CheckIcmpChecksum(sequence, ipOffset, icmpOffset); err != nil {
+		t.Fatalf("ERROR: %v\n", err)
+	}
+}
+
 func (o *OtgApi) GetCapture(portName string) *CapturedPackets {
 	t := o.Testing()
 	api := o.Api()
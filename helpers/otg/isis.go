@@ -0,0 +1,592 @@
+package otg
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+type IsisTopologyType string
+
+const (
+	IsisTopologyGrid IsisTopologyType = "grid"
+	IsisTopologyRing IsisTopologyType = "ring"
+	IsisTopologyTree IsisTopologyType = "tree"
+)
+
+type IsisTopologySpec struct {
+	// prefix used for names of generated devices
+	Name string
+	Type IsisTopologyType
+	// grid has Rows x Cols nodes, whereas ring and tree have NodeCount nodes
+	Rows      int
+	Cols      int
+	NodeCount int
+	// number of children of each node in tree
+	Fanout int
+	// system ID of first node, incremented for each subsequent node
+	StartSystemId string
+	AreaAddresses []string
+	LevelType     gosnappi.IsisInterfaceLevelTypeEnum
+	LinkMetric    uint32
+	// MAC of first simulated interface, incremented for each subsequent one
+	StartMac string
+	// links, including the one towards attached router, are addressed from
+	// consecutive /30 subnets starting at StartLinkIpv4
+	StartLinkIpv4 string
+	// each node advertises RoutesPerNode routes from its own block of
+	// consecutive prefixes; not advertised when start address is empty
+	StartRouteV4  string
+	RouteV4Prefix uint32
+	StartRouteV6  string
+	RouteV6Prefix uint32
+	RoutesPerNode uint32
+}
+
+type IsisTopologyNode struct {
+	Name        string
+	SystemId    string
+	V4RouteName string
+	V6RouteName string
+	// first address advertised by node
+	V4Address string
+	V6Address string
+}
+
+type IsisTopology struct {
+	spec  IsisTopologySpec
+	Nodes []IsisTopologyNode
+	// pairs of node indices connected by a simulated link
+	Links [][2]int
+}
+
+// AddIsisTopology adds simulated ISIS routers, as per spec, to config and
+// connects first of them to ISIS router of attachTo using a simulated link
+func (o *OtgApi) AddIsisTopology(c gosnappi.Config, attachTo gosnappi.Device, spec IsisTopologySpec) *IsisTopology {
+	t := o.Testing()
+
+	if !attachTo.HasIsis() {
+		t.Fatalf("ERROR: Device %s does not have ISIS configured\n", attachTo.Name())
+	}
+
+	nodeCount := spec.NodeCount
+	links := [][2]int{}
+	switch spec.Type {
+	case IsisTopologyGrid:
+		if spec.Rows <= 0 || spec.Cols <= 0 {
+			t.Fatalf("ERROR: Rows %d and Cols %d must be positive for grid\n", spec.Rows, spec.Cols)
+		}
+		nodeCount = spec.Rows * spec.Cols
+		for r := 0; r < spec.Rows; r++ {
+			for c := 0; c < spec.Cols; c++ {
+				i := r*spec.Cols + c
+				if c+1 < spec.Cols {
+					links = append(links, [2]int{i, i + 1})
+				}
+				if r+1 < spec.Rows {
+					links = append(links, [2]int{i, i + spec.Cols})
+				}
+			}
+		}
+	case IsisTopologyRing:
+		if nodeCount < 3 {
+			t.Fatalf("ERROR: NodeCount %d must be at least 3 for ring\n", nodeCount)
+		}
+		for i := 0; i < nodeCount; i++ {
+			links = append(links, [2]int{i, (i + 1) % nodeCount})
+		}
+	case IsisTopologyTree:
+		if nodeCount <= 0 || spec.Fanout <= 0 {
+			t.Fatalf("ERROR: NodeCount %d and Fanout %d must be positive for tree\n", nodeCount, spec.Fanout)
+		}
+		for i := 1; i < nodeCount; i++ {
+			links = append(links, [2]int{(i - 1) / spec.Fanout, i})
+		}
+	default:
+		t.Fatalf("ERROR: Unsupported ISIS topology type %q\n", spec.Type)
+	}
+
+	systemId, err := strconv.ParseUint(spec.StartSystemId, 16, 48)
+	if err != nil {
+		t.Fatalf("ERROR: Could not parse system ID %s: %v\n", spec.StartSystemId, err)
+	}
+	mac, err := net.ParseMAC(spec.StartMac)
+	if err != nil || len(mac) != 6 {
+		t.Fatalf("ERROR: Could not parse MAC %s: %v\n", spec.StartMac, err)
+	}
+	linkIp := net.ParseIP(spec.StartLinkIpv4).To4()
+	if linkIp == nil {
+		t.Fatalf("ERROR: Could not parse link IPv4 address %s\n", spec.StartLinkIpv4)
+	}
+	if spec.StartRouteV4 != "" && (net.ParseIP(spec.StartRouteV4).To4() == nil || spec.RouteV4Prefix == 0 || spec.RouteV4Prefix > 32) {
+		t.Fatalf("ERROR: Invalid IPv4 route %s/%d\n", spec.StartRouteV4, spec.RouteV4Prefix)
+	}
+	if spec.StartRouteV6 != "" && (net.ParseIP(spec.StartRouteV6) == nil || spec.RouteV6Prefix == 0 || spec.RouteV6Prefix > 128) {
+		t.Fatalf("ERROR: Invalid IPv6 route %s/%d\n", spec.StartRouteV6, spec.RouteV6Prefix)
+	}
+	if spec.RoutesPerNode == 0 {
+		spec.RoutesPerNode = 1
+	}
+	if spec.Name == "" {
+		spec.Name = "sim"
+	}
+	if spec.LevelType == "" {
+		spec.LevelType = gosnappi.IsisInterfaceLevelType.LEVEL_2
+	}
+	if spec.LinkMetric == 0 {
+		spec.LinkMetric = 10
+	}
+
+	topo := &IsisTopology{
+		spec:  spec,
+		Nodes: make([]IsisTopologyNode, 0, nodeCount),
+		Links: links,
+	}
+
+	devices := make([]gosnappi.Device, 0, nodeCount)
+	for i := 0; i < nodeCount; i++ {
+		node := IsisTopologyNode{
+			Name:     fmt.Sprintf("%s%d", spec.Name, i+1),
+			SystemId: fmt.Sprintf("%012x", systemId+uint64(i)),
+		}
+
+		d := c.Devices().Add().SetName(node.Name)
+		isis := d.Isis().
+			SetSystemId(node.SystemId).
+			SetName(node.Name + "Isis")
+
+		isis.Basic().
+			SetIpv4TeRouterId(isisIpAdd(linkIp, uint64(4*(len(links)+1+i)), false)).
+			SetHostname(isis.Name())
+
+		isis.Advanced().
+			SetAreaAddresses(spec.AreaAddresses).
+			SetEnableAttachedBit(false)
+
+		if spec.StartRouteV4 != "" {
+			block := new(big.Int).Lsh(big.NewInt(1), uint(32-spec.RouteV4Prefix))
+			offset := block.Mul(block, big.NewInt(int64(i)*int64(spec.RoutesPerNode)))
+			node.V4RouteName = node.Name + "IsisRrV4"
+			node.V4Address = isisIpAddBig(net.ParseIP(spec.StartRouteV4).To4(), offset, false)
+
+			rr := isis.V4Routes().Add().SetName(node.V4RouteName).SetLinkMetric(spec.LinkMetric)
+			rr.Addresses().Add().
+				SetAddress(node.V4Address).
+				SetPrefix(spec.RouteV4Prefix).
+				SetCount(spec.RoutesPerNode).
+				SetStep(1)
+		}
+
+		if spec.StartRouteV6 != "" {
+			block := new(big.Int).Lsh(big.NewInt(1), uint(128-spec.RouteV6Prefix))
+			offset := block.Mul(block, big.NewInt(int64(i)*int64(spec.RoutesPerNode)))
+			node.V6RouteName = node.Name + "IsisRrV6"
+			node.V6Address = isisIpAddBig(net.ParseIP(spec.StartRouteV6).To16(), offset, true)
+
+			rr := isis.V6Routes().Add().SetName(node.V6RouteName).SetLinkMetric(spec.LinkMetric)
+			rr.Addresses().Add().
+				SetAddress(node.V6Address).
+				SetPrefix(spec.RouteV6Prefix).
+				SetCount(spec.RoutesPerNode).
+				SetStep(1)
+		}
+
+		topo.Nodes = append(topo.Nodes, node)
+		devices = append(devices, d)
+	}
+
+	ethCount := uint64(0)
+	addLink := func(d gosnappi.Device, ethName string, remoteEthName string, subnet int, primary bool) {
+		eth := d.Ethernets().
+			Add().
+			SetName(ethName).
+			SetMac(isisMacAdd(mac, ethCount)).
+			SetMtu(1500)
+		ethCount += 1
+
+		linkType := gosnappi.EthernetSimulatedLinkLinkType.PRIMARY
+		host := uint64(1)
+		if !primary {
+			linkType = gosnappi.EthernetSimulatedLinkLinkType.SECONDARY
+			host = 2
+		}
+		eth.Connection().SimulatedLink().
+			SetRemoteSimulatedLink(remoteEthName).
+			SetLinkType(linkType)
+
+		eth.Ipv4Addresses().
+			Add().
+			SetName(ethName + "Ip").
+			SetAddress(isisIpAdd(linkIp, uint64(4*subnet)+host, false)).
+			SetGateway(isisIpAdd(linkIp, uint64(4*subnet)+3-host, false)).
+			SetPrefix(30)
+
+		d.Isis().Interfaces().
+			Add().
+			SetEthName(eth.Name()).
+			SetName(ethName + "IsisInt").
+			SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+			SetLevelType(spec.LevelType).
+			SetMetric(spec.LinkMetric)
+	}
+
+	attachEth := fmt.Sprintf("%sTo%s1Eth", attachTo.Name(), spec.Name)
+	nodeEth := fmt.Sprintf("%s1To%sEth", spec.Name, attachTo.Name())
+	addLink(attachTo, attachEth, nodeEth, 0, true)
+	addLink(devices[0], nodeEth, attachEth, 0, false)
+
+	for i, l := range links {
+		aEth := fmt.Sprintf("%sTo%sEth", topo.Nodes[l[0]].Name, topo.Nodes[l[1]].Name)
+		bEth := fmt.Sprintf("%sTo%sEth", topo.Nodes[l[1]].Name, topo.Nodes[l[0]].Name)
+		addLink(devices[l[0]], aEth, bEth, i+1, true)
+		addLink(devices[l[1]], bEth, aEth, i+1, false)
+	}
+
+	return topo
+}
+
+// NodeCount returns number of simulated routers, each of which originates
+// exactly one LSP per level
+func (t *IsisTopology) NodeCount() int {
+	return len(t.Nodes)
+}
+
+func (t *IsisTopology) V4RouteNames() []string {
+	names := []string{}
+	for _, n := range t.Nodes {
+		if n.V4RouteName != "" {
+			names = append(names, n.V4RouteName)
+		}
+	}
+	return names
+}
+
+func (t *IsisTopology) V4Addresses() []string {
+	addresses := []string{}
+	for _, n := range t.Nodes {
+		if n.V4Address != "" {
+			addresses = append(addresses, n.V4Address)
+		}
+	}
+	return addresses
+}
+
+func (t *IsisTopology) V6RouteNames() []string {
+	names := []string{}
+	for _, n := range t.Nodes {
+		if n.V6RouteName != "" {
+			names = append(names, n.V6RouteName)
+		}
+	}
+	return names
+}
+
+func (t *IsisTopology) V6Addresses() []string {
+	addresses := []string{}
+	for _, n := range t.Nodes {
+		if n.V6Address != "" {
+			addresses = append(addresses, n.V6Address)
+		}
+	}
+	return addresses
+}
+
+func isisIpAdd(ip net.IP, offset uint64, isV6 bool) string {
+	return isisIpAddBig(ip, new(big.Int).SetUint64(offset), isV6)
+}
+
+func isisIpAddBig(ip net.IP, offset *big.Int, isV6 bool) string {
+	v := new(big.Int).SetBytes(ip)
+	return bgpIntToIp(v.Add(v, offset), isV6)
+}
+
+func isisMacAdd(mac net.HardwareAddr, offset uint64) string {
+	v := uint64(0)
+	for _, b := range mac {
+		v = v<<8 | uint64(b)
+	}
+	v += offset
+	out := make(net.HardwareAddr, 6)
+	for i := 5; i >= 0; i-- {
+		out[i] = byte(v)
+		v >>= 8
+	}
+	return out.String()
+}
+
+// isisLspTlvRows returns TLV, value, metric and SIDs of each entry of decoded TLVs
+func isisLspTlvRows(tlvs gosnappi.IsisLspTlvs) [][]interface{} {
+	rows := [][]interface{}{}
+
+	for _, h := range tlvs.HostnameTlvs().Items() {
+		rows = append(rows, []interface{}{"Hostname", h.Hostname(), "", ""})
+	}
+	for _, r := range tlvs.IsReachabilityTlvs().Items() {
+		for _, n := range r.Neighbors().Items() {
+			rows = append(rows, []interface{}{"IS Reachability", n.SystemId(), "", ""})
+		}
+	}
+	for _, r := range tlvs.ExtendedIsReachabilityTlvs().Items() {
+		for _, n := range r.Neighbors().Items() {
+			rows = append(rows, []interface{}{"Ext. IS Reachability", n.SystemId(), "", isisAdjacencySids(n)})
+		}
+	}
+	for _, r := range tlvs.Ipv4InternalReachabilityTlvs().Items() {
+		for _, p := range r.Prefixes().Items() {
+			rows = append(rows, []interface{}{
+				"IPv4 Int. Reachability", fmt.Sprintf("%s/%d", p.Ipv4Address(), p.PrefixLength()), p.DefaultMetric(), "",
+			})
+		}
+	}
+	for _, r := range tlvs.Ipv4ExternalReachabilityTlvs().Items() {
+		for _, p := range r.Prefixes().Items() {
+			rows = append(rows, []interface{}{
+				"IPv4 Ext. Reachability", fmt.Sprintf("%s/%d", p.Ipv4Address(), p.PrefixLength()), p.DefaultMetric(), "",
+			})
+		}
+	}
+	for _, r := range tlvs.ExtendedIpv4ReachabilityTlvs().Items() {
+		for _, p := range r.Prefixes().Items() {
+			rows = append(rows, []interface{}{
+				"Ext. IPv4 Reachability", fmt.Sprintf("%s/%d", p.Ipv4Address(), p.PrefixLength()), p.Metric(),
+				isisV4PrefixSids(p),
+			})
+		}
+	}
+	for _, r := range tlvs.Ipv6ReachabilityTlvs().Items() {
+		for _, p := range r.Prefixes().Items() {
+			rows = append(rows, []interface{}{
+				"IPv6 Reachability", fmt.Sprintf("%s/%d", p.Ipv6Address(), p.PrefixLength()), p.Metric(),
+				isisV6PrefixSids(p),
+			})
+		}
+	}
+	for _, c := range tlvs.RouterCapabilities().Items() {
+		rows = append(rows, []interface{}{"Router Capability", c.RouterCapId(), "", ""})
+		for _, r := range c.SrCapability().SrgbRanges().Items() {
+			rows = append(rows, []interface{}{
+				"SR Capability SRGB", fmt.Sprintf("%d+%d", r.StartingSid(), r.Range()), "", "",
+			})
+		}
+	}
+
+	return rows
+}
+
+func isisAdjacencySids(n gosnappi.IsisLspExtendedNeighbor) []uint32 {
+	sids := []uint32{}
+	for _, a := range n.AdjacencySids().Items() {
+		sids = append(sids, a.Sids()...)
+	}
+	return sids
+}
+
+func isisV4PrefixSids(p gosnappi.IsisLspExtendedV4Prefix) []uint32 {
+	sids := []uint32{}
+	for _, s := range p.PrefixSids().Items() {
+		sids = append(sids, s.Sids()...)
+	}
+	return sids
+}
+
+func isisV6PrefixSids(p gosnappi.IsisLspV6Prefix) []uint32 {
+	sids := []uint32{}
+	for _, s := range p.PrefixSids().Items() {
+		sids = append(sids, s.Sids()...)
+	}
+	return sids
+}
+
+type IsisPrefixMatch struct {
+	Address      string
+	PrefixLength uint32
+	// not checked when zero
+	Metric uint32
+	// SID indices or labels advertised in prefix SID sub-TLVs; not checked when empty
+	PrefixSids []uint32
+}
+
+type IsisSrgbRange struct {
+	StartingSid uint32
+	Range       uint32
+}
+
+// IsisLspMatch describes expected content of an LSP learnt by an ISIS router;
+// empty fields are not checked
+type IsisLspMatch struct {
+	// ISIS router which learnt the LSP
+	RouterName string
+	LspId      string
+	PduType    gosnappi.IsisLspStatePduTypeEnum
+	Hostname   string
+	// system IDs advertised in IS or extended IS reachability TLVs
+	IsNeighbors []string
+	// prefixes advertised in IPv4 internal, external or extended reachability TLVs
+	V4Prefixes []IsisPrefixMatch
+	V6Prefixes []IsisPrefixMatch
+	// router capability ID advertised in router capability TLV
+	RouterCapId string
+	// SRGB ranges advertised in SR capability sub-TLV
+	SrgbRanges []IsisSrgbRange
+	// SIDs advertised in adjacency SID sub-TLVs of extended IS reachability TLVs
+	AdjacencySids []uint32
+}
+
+func (m *IsisLspMatch) findLsp(states []gosnappi.IsisLspsState) gosnappi.IsisLspState {
+	for _, v := range states {
+		if m.RouterName != "" && v.IsisRouterName() != m.RouterName {
+			continue
+		}
+		for _, w := range v.Lsps().Items() {
+			if w.LspId() == m.LspId && (m.PduType == "" || w.PduType() == m.PduType) {
+				return w
+			}
+		}
+	}
+	return nil
+}
+
+// Check ensures that LSP is learnt and carries expected TLV content
+func (m *IsisLspMatch) Check(states []gosnappi.IsisLspsState) error {
+	lsp := m.findLsp(states)
+	if lsp == nil {
+		return fmt.Errorf("router %s: %s LSP %s not learnt", m.RouterName, m.PduType, m.LspId)
+	}
+	tlvs := lsp.Tlvs()
+
+	if m.Hostname != "" {
+		found := false
+		for _, h := range tlvs.HostnameTlvs().Items() {
+			if h.Hostname() == m.Hostname {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("LSP %s: hostname %s not advertised", m.LspId, m.Hostname)
+		}
+	}
+
+	neighbors := map[string]bool{}
+	for _, r := range tlvs.IsReachabilityTlvs().Items() {
+		for _, n := range r.Neighbors().Items() {
+			neighbors[n.SystemId()] = true
+		}
+	}
+	adjSids := map[uint32]bool{}
+	for _, r := range tlvs.ExtendedIsReachabilityTlvs().Items() {
+		for _, n := range r.Neighbors().Items() {
+			neighbors[n.SystemId()] = true
+			for _, sid := range isisAdjacencySids(n) {
+				adjSids[sid] = true
+			}
+		}
+	}
+	for _, n := range m.IsNeighbors {
+		// neighbor system ID may be reported with pseudonode ID appended
+		if !neighbors[n] && !neighbors[n+"00"] && !neighbors[n+"-00"] {
+			return fmt.Errorf("LSP %s: IS neighbor %s not advertised", m.LspId, n)
+		}
+	}
+	for _, sid := range m.AdjacencySids {
+		if !adjSids[sid] {
+			return fmt.Errorf("LSP %s: adjacency SID %d not advertised", m.LspId, sid)
+		}
+	}
+
+	v4Prefixes := map[string]isisLspPrefix{}
+	for _, r := range tlvs.Ipv4InternalReachabilityTlvs().Items() {
+		for _, p := range r.Prefixes().Items() {
+			v4Prefixes[maskedPrefixKey(p.Ipv4Address(), p.PrefixLength())] = isisLspPrefix{metric: p.DefaultMetric()}
+		}
+	}
+	for _, r := range tlvs.Ipv4ExternalReachabilityTlvs().Items() {
+		for _, p := range r.Prefixes().Items() {
+			v4Prefixes[maskedPrefixKey(p.Ipv4Address(), p.PrefixLength())] = isisLspPrefix{metric: p.DefaultMetric()}
+		}
+	}
+	for _, r := range tlvs.ExtendedIpv4ReachabilityTlvs().Items() {
+		for _, p := range r.Prefixes().Items() {
+			v4Prefixes[maskedPrefixKey(p.Ipv4Address(), p.PrefixLength())] = isisLspPrefix{metric: p.Metric(), sids: isisV4PrefixSids(p)}
+		}
+	}
+	if err := isisCheckPrefixes(m.LspId, v4Prefixes, m.V4Prefixes); err != nil {
+		return err
+	}
+
+	v6Prefixes := map[string]isisLspPrefix{}
+	for _, r := range tlvs.Ipv6ReachabilityTlvs().Items() {
+		for _, p := range r.Prefixes().Items() {
+			v6Prefixes[maskedPrefixKey(p.Ipv6Address(), p.PrefixLength())] = isisLspPrefix{metric: p.Metric(), sids: isisV6PrefixSids(p)}
+		}
+	}
+	if err := isisCheckPrefixes(m.LspId, v6Prefixes, m.V6Prefixes); err != nil {
+		return err
+	}
+
+	if m.RouterCapId != "" {
+		found := false
+		for _, c := range tlvs.RouterCapabilities().Items() {
+			if c.RouterCapId() == m.RouterCapId {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("LSP %s: router capability ID %s not advertised", m.LspId, m.RouterCapId)
+		}
+	}
+
+	srgbRanges := map[IsisSrgbRange]bool{}
+	for _, c := range tlvs.RouterCapabilities().Items() {
+		for _, r := range c.SrCapability().SrgbRanges().Items() {
+			srgbRanges[IsisSrgbRange{StartingSid: r.StartingSid(), Range: r.Range()}] = true
+		}
+	}
+	for _, r := range m.SrgbRanges {
+		if !srgbRanges[r] {
+			return fmt.Errorf("LSP %s: SRGB range %d+%d not advertised", m.LspId, r.StartingSid, r.Range)
+		}
+	}
+
+	return nil
+}
+
+type isisLspPrefix struct {
+	metric uint32
+	sids   []uint32
+}
+
+func isisCheckPrefixes(lspId string, actual map[string]isisLspPrefix, expected []IsisPrefixMatch) error {
+	for _, p := range expected {
+		key := maskedPrefixKey(p.Address, p.PrefixLength)
+		act, ok := actual[key]
+		if !ok {
+			return fmt.Errorf("LSP %s: prefix %s not advertised", lspId, key)
+		}
+		if p.Metric != 0 && p.Metric != act.metric {
+			return fmt.Errorf("LSP %s: prefix %s: expMetric %d != actMetric %d", lspId, key, p.Metric, act.metric)
+		}
+		if len(p.PrefixSids) != 0 && fmt.Sprint(p.PrefixSids) != fmt.Sprint(act.sids) {
+			return fmt.Errorf("LSP %s: prefix %s: expPrefixSids %v != actPrefixSids %v", lspId, key, p.PrefixSids, act.sids)
+		}
+	}
+	return nil
+}
+
+func (m *IsisLspMatch) Has(t *testing.T, states []gosnappi.IsisLspsState) bool {
+	if err := m.Check(states); err != nil {
+		t.Logf("WARNING: %v\n", err)
+		return false
+	}
+
+	return true
+}
+
+func (m *IsisLspMatch) Validate(t *testing.T, states []gosnappi.IsisLspsState) {
+	if err := m.Check(states); err != nil {
+		t.Fatalf("ERROR: %v\n", err)
+	}
+}
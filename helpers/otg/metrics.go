@@ -22,10 +22,13 @@ func (o *OtgApi) GetPortMetrics() []gosnappi.PortMetric {
 	tb := table.NewTable(
 		"Port Metrics",
 		[]string{
+			"Name",
 			"Transmit",
 			"Location",
 			"Link",
 			"Capture",
+			"Frames Tx",
+			"Frames Rx",
 			"FPS Tx",
 			"FPS Rx",
 			"Bytes Tx",
@@ -36,10 +39,13 @@ func (o *OtgApi) GetPortMetrics() []gosnappi.PortMetric {
 	for _, v := range res.PortMetrics().Items() {
 		if v != nil {
 			tb.AppendRow([]interface{}{
+				v.Name(),
 				v.Transmit(),
 				v.Location(),
 				v.Link(),
 				v.Capture(),
+				v.FramesTx(),
+				v.FramesRx(),
 				v.FramesTxRate(),
 				v.FramesRxRate(),
 				v.BytesTx(),
@@ -134,6 +140,43 @@ func (o *OtgApi) GetBgpv4Metrics() []gosnappi.Bgpv4Metric {
 	return res.Bgpv4Metrics().Items()
 }
 
+func (o *OtgApi) GetBgpv6Metrics() []gosnappi.Bgpv6Metric {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting bgpv6 metrics ...")
+	defer o.Timer(time.Now(), "GetBgpv6Metrics")
+
+	mr := gosnappi.NewMetricsRequest()
+	mr.Bgpv6()
+	res, err := api.GetMetrics(mr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"BGPv6 Metrics",
+		[]string{
+			"Name",
+			"State",
+			"Routes Adv.",
+			"Routes Rec.",
+		},
+		15,
+	)
+	for _, v := range res.Bgpv6Metrics().Items() {
+		if v != nil {
+			tb.AppendRow([]interface{}{
+				v.Name(),
+				v.SessionState(),
+				v.RoutesAdvertised(),
+				v.RoutesReceived(),
+			})
+		}
+	}
+
+	t.Log(tb.String())
+	return res.Bgpv6Metrics().Items()
+}
+
 func (o *OtgApi) GetIsIsMetrics() []gosnappi.IsisMetric {
 	t := o.Testing()
 	api := o.Api()
@@ -228,6 +271,10 @@ func (o *OtgApi) GetLldpMetrics() []gosnappi.LldpMetric {
 			"Name",
 			"Frames Tx",
 			"Frames Rx",
+			"Frames Error Rx",
+			"Frames Discard",
+			"TLVs Discard",
+			"TLVs Unknown",
 		},
 		15,
 	)
@@ -237,6 +284,10 @@ func (o *OtgApi) GetLldpMetrics() []gosnappi.LldpMetric {
 				v.Name(),
 				v.FramesTx(),
 				v.FramesRx(),
+				v.FramesErrorRx(),
+				v.FramesDiscard(),
+				v.TlvsDiscard(),
+				v.TlvsUnknown(),
 			})
 		}
 	}
@@ -262,6 +313,7 @@ func (o *OtgApi) GetLagMetrics() []gosnappi.LagMetric {
 		[]string{
 			"Name",
 			"Oper Status",
+			"Members Up",
 			"Frames Tx",
 			"Frames Rx",
 			"FPS Tx",
@@ -276,6 +328,7 @@ func (o *OtgApi) GetLagMetrics() []gosnappi.LagMetric {
 			tb.AppendRow([]interface{}{
 				v.Name(),
 				v.OperStatus(),
+				v.MemberPortsUp(),
 				v.FramesTx(),
 				v.FramesRx(),
 				v.FramesTxRate(),
@@ -309,10 +362,20 @@ func (o *OtgApi) GetLacpMetrics() []gosnappi.LacpMetric {
 			"LAG Member Port",
 			"System ID",
 			"Partner ID",
+			"Key",
+			"Partner Key",
+			"Port",
+			"Partner Port",
+			"Activity",
+			"Timeout",
+			"Sync",
+			"Aggregatable",
+			"Collecting",
+			"Distributing",
 			"LACP Packets Tx",
 			"LACP Packets Rx",
 		},
-		20,
+		18,
 	)
 	for _, v := range res.LacpMetrics().Items() {
 		if v != nil {
@@ -321,6 +384,16 @@ func (o *OtgApi) GetLacpMetrics() []gosnappi.LacpMetric {
 				v.LagMemberPortName(),
 				v.SystemId(),
 				v.PartnerId(),
+				v.OperKey(),
+				v.PartnerKey(),
+				v.PortNum(),
+				v.PartnerPortNum(),
+				v.Activity(),
+				v.Timeout(),
+				v.Synchronization(),
+				v.Aggregatable(),
+				v.Collecting(),
+				v.Distributing(),
 				v.LacpPacketsTx(),
 				v.LacpPacketsRx(),
 			})
@@ -367,3 +440,242 @@ func (o *OtgApi) GetOspfv3Metrics() []gosnappi.Ospfv3Metric {
 	t.Log(tb.String())
 	return res.Ospfv3Metrics().Items()
 }
+
+func (o *OtgApi) GetRsvpMetrics() []gosnappi.RsvpMetric {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting RSVP metrics ...")
+	defer o.Timer(time.Now(), "GetRsvpMetrics")
+
+	mr := gosnappi.NewMetricsRequest()
+	mr.Rsvp()
+	res, err := api.GetMetrics(mr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"RSVP Metrics",
+		[]string{
+			"Name",
+			"Ingress Configured",
+			"Ingress Up",
+			"Egress Up",
+			"LSP Flaps",
+			"Paths Tx",
+			"Paths Rx",
+			"Resvs Tx",
+			"Resvs Rx",
+			"Path Errors Rx",
+			"Resv Errors Rx",
+			"Path Tears Rx",
+			"Resv Tears Rx",
+		},
+		16,
+	)
+	for _, v := range res.RsvpMetrics().Items() {
+		if v != nil {
+			tb.AppendRow([]interface{}{
+				v.Name(),
+				v.IngressP2PLspsConfigured(),
+				v.IngressP2PLspsUp(),
+				v.EgressP2PLspsUp(),
+				v.LspFlapCount(),
+				v.PathsTx(),
+				v.PathsRx(),
+				v.ResvsTx(),
+				v.ResvsRx(),
+				v.PathErrorsRx(),
+				v.ResvErrorsRx(),
+				v.PathTearsRx(),
+				v.ResvTearsRx(),
+			})
+		}
+	}
+
+	t.Log(tb.String())
+	return res.RsvpMetrics().Items()
+}
+
+func (o *OtgApi) GetDhcpv4ClientMetrics() []gosnappi.Dhcpv4ClientMetric {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting DHCPv4 client metrics ...")
+	defer o.Timer(time.Now(), "GetDhcpv4ClientMetrics")
+
+	mr := gosnappi.NewMetricsRequest()
+	mr.Dhcpv4Client()
+	res, err := api.GetMetrics(mr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"DHCPv4 Client Metrics",
+		[]string{
+			"Name",
+			"Discovers Sent",
+			"Offers Received",
+			"Requests Sent",
+			"Acks Received",
+			"Nacks Received",
+			"Releases Sent",
+			"Declines Sent",
+		},
+		16,
+	)
+	for _, v := range res.Dhcpv4ClientMetrics().Items() {
+		if v != nil {
+			tb.AppendRow([]interface{}{
+				v.Name(),
+				v.DiscoversSent(),
+				v.OffersReceived(),
+				v.RequestsSent(),
+				v.AcksReceived(),
+				v.NacksReceived(),
+				v.ReleasesSent(),
+				v.DeclinesSent(),
+			})
+		}
+	}
+
+	t.Log(tb.String())
+	return res.Dhcpv4ClientMetrics().Items()
+}
+
+func (o *OtgApi) GetDhcpv4ServerMetrics() []gosnappi.Dhcpv4ServerMetric {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting DHCPv4 server metrics ...")
+	defer o.Timer(time.Now(), "GetDhcpv4ServerMetrics")
+
+	mr := gosnappi.NewMetricsRequest()
+	mr.Dhcpv4Server()
+	res, err := api.GetMetrics(mr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"DHCPv4 Server Metrics",
+		[]string{
+			"Name",
+			"Discovers Received",
+			"Offers Sent",
+			"Requests Received",
+			"Acks Sent",
+			"Nacks Sent",
+			"Releases Received",
+			"Declines Received",
+		},
+		18,
+	)
+	for _, v := range res.Dhcpv4ServerMetrics().Items() {
+		if v != nil {
+			tb.AppendRow([]interface{}{
+				v.Name(),
+				v.DiscoversReceived(),
+				v.OffersSent(),
+				v.RequestsReceived(),
+				v.AcksSent(),
+				v.NacksSent(),
+				v.ReleasesReceived(),
+				v.DeclinesReceived(),
+			})
+		}
+	}
+
+	t.Log(tb.String())
+	return res.Dhcpv4ServerMetrics().Items()
+}
+
+func (o *OtgApi) GetDhcpv6ClientMetrics() []gosnappi.Dhcpv6ClientMetric {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting DHCPv6 client metrics ...")
+	defer o.Timer(time.Now(), "GetDhcpv6ClientMetrics")
+
+	mr := gosnappi.NewMetricsRequest()
+	mr.Dhcpv6Client()
+	res, err := api.GetMetrics(mr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"DHCPv6 Client Metrics",
+		[]string{
+			"Name",
+			"Solicits Sent",
+			"Advertisements Received",
+			"Advertisements Ignored",
+			"Requests Sent",
+			"Nacks Received",
+			"Replies Received",
+			"Renews Sent",
+			"Releases Sent",
+		},
+		16,
+	)
+	for _, v := range res.Dhcpv6ClientMetrics().Items() {
+		if v != nil {
+			tb.AppendRow([]interface{}{
+				v.Name(),
+				v.SolicitsSent(),
+				v.AdvertisementsReceived(),
+				v.AdvertisementsIgnored(),
+				v.RequestsSent(),
+				v.NacksReceived(),
+				v.RepliesReceived(),
+				v.RenewsSent(),
+				v.ReleasesSent(),
+			})
+		}
+	}
+
+	t.Log(tb.String())
+	return res.Dhcpv6ClientMetrics().Items()
+}
+
+func (o *OtgApi) GetDhcpv6ServerMetrics() []gosnappi.Dhcpv6ServerMetric {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting DHCPv6 server metrics ...")
+	defer o.Timer(time.Now(), "GetDhcpv6ServerMetrics")
+
+	mr := gosnappi.NewMetricsRequest()
+	mr.Dhcpv6Server()
+	res, err := api.GetMetrics(mr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"DHCPv6 Server Metrics",
+		[]string{
+			"Name",
+			"Solicits Received",
+			"Solicits Ignored",
+			"Advertisements Sent",
+			"Requests Received",
+			"Nacks Sent",
+			"Replies Sent",
+			"Renewals Received",
+			"Releases Received",
+		},
+		16,
+	)
+	for _, v := range res.Dhcpv6ServerMetrics().Items() {
+		if v != nil {
+			tb.AppendRow([]interface{}{
+				v.Name(),
+				v.SolicitsReceived(),
+				v.SolicitsIgnored(),
+				v.AdvertisementsSent(),
+				v.RequestsReceived(),
+				v.NacksSent(),
+				v.RepliesSent(),
+				v.RenewalsReceived(),
+				v.ReleasesReceived(),
+			})
+		}
+	}
+
+	t.Log(tb.String())
+	return res.Dhcpv6ServerMetrics().Items()
+}
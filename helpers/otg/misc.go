@@ -1,6 +1,8 @@
 package otg
 
 import (
+	"fmt"
+	"net"
 	"time"
 
 	"github.com/open-traffic-generator/conformance/helpers/plot"
@@ -57,6 +59,41 @@ func (o *OtgApi) WaitFor(fn func() bool, opts *WaitForOpts) {
 	}
 }
 
+// EnsureFor fails the test as soon as fn returns false before opts.Timeout
+// has elapsed, e.g. to check that a session stays up for a given duration
+func (o *OtgApi) EnsureFor(fn func() bool, opts *WaitForOpts) {
+	t := o.Testing()
+
+	if opts == nil {
+		opts = &WaitForOpts{
+			FnName: "EnsureFor",
+		}
+	}
+	defer o.Timer(time.Now(), opts.FnName)
+
+	if opts.Interval == 0 {
+		opts.Interval = 500 * time.Millisecond
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	start := time.Now()
+	t.Logf("Ensuring %s for %v ...\n", opts.FnName, opts.Timeout)
+
+	for {
+		if !fn() {
+			t.Fatalf("ERROR: Condition failed after %v while ensuring %s\n", time.Since(start), opts.FnName)
+		}
+
+		if time.Since(start) > opts.Timeout {
+			t.Logf("Done ensuring %s\n", opts.FnName)
+			return
+		}
+		time.Sleep(opts.Interval)
+	}
+}
+
 func (o *OtgApi) LogWrnErr(wrn gosnappi.Warning, err error, exitOnErr bool) {
 	t := o.Testing()
 	if wrn != nil {
@@ -108,3 +145,18 @@ func (o *OtgApi) Layer1SpeedToMpbs(speed string) int {
 		return 0
 	}
 }
+
+// maskedPrefixKey masks address to prefix length, such that a route advertised
+// with host bits set matches the prefix carried in protocol state
+func maskedPrefixKey(address string, prefixLength uint32) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return fmt.Sprintf("%s/%d", address, prefixLength)
+	}
+	bits := 128
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+		bits = 32
+	}
+	return fmt.Sprintf("%s/%d", ip.Mask(net.CIDRMask(int(prefixLength), bits)), prefixLength)
+}
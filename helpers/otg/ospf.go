@@ -0,0 +1,365 @@
+package otg
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+type OspfLsaType string
+
+const (
+	OspfLsaRouter          OspfLsaType = "router"
+	OspfLsaNetwork         OspfLsaType = "network"
+	OspfLsaNetworkSummary  OspfLsaType = "network_summary"
+	OspfLsaSummaryAs       OspfLsaType = "summary_as"
+	OspfLsaExternalAs      OspfLsaType = "external_as"
+	OspfLsaNssa            OspfLsaType = "nssa"
+	OspfLsaOpaque          OspfLsaType = "opaque"
+	OspfLsaInterAreaPrefix OspfLsaType = "inter_area_prefix"
+	OspfLsaInterAreaRouter OspfLsaType = "inter_area_router"
+	OspfLsaLink            OspfLsaType = "link"
+	OspfLsaIntraAreaPrefix OspfLsaType = "intra_area_prefix"
+)
+
+// OspfLsa is a flattened view of an OSPFv2 or OSPFv3 LSA stored by a router;
+// router LSAs are flattened into one entry per link
+type OspfLsa struct {
+	// router which stored the LSA
+	RouterName          string
+	Type                OspfLsaType
+	LsaId               string
+	AdvertisingRouterId string
+	// type, ID and data of router LSA link; ID of OSPFv3 router LSA link is
+	// the neighbor router ID
+	LinkType string
+	LinkId   string
+	LinkData string
+	// prefix in address/length form, for stub links and prefix carrying LSAs
+	Prefix            string
+	Metric            uint32
+	MetricType        uint32
+	ForwardingAddress string
+	// attached routers of network LSA, or link local address of link LSA
+	Extra string
+}
+
+func ospfMaskLength(mask string) uint32 {
+	ip := net.ParseIP(mask).To4()
+	if ip == nil {
+		return 0
+	}
+	ones, _ := net.IPMask(ip).Size()
+	return uint32(ones)
+}
+
+func ospfv2Prefix(address string, mask string) string {
+	return maskedPrefixKey(address, ospfMaskLength(mask))
+}
+
+func FlattenOspfv2Lsas(states []gosnappi.Ospfv2LsaState) []OspfLsa {
+	lsas := []OspfLsa{}
+
+	for _, v := range states {
+		name := v.RouterName()
+		for _, l := range v.RouterLsas().Items() {
+			for _, k := range l.Links().Items() {
+				lsa := OspfLsa{
+					RouterName:          name,
+					Type:                OspfLsaRouter,
+					LsaId:               l.Header().LsaId(),
+					AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+					LinkType:            string(k.Type()),
+					LinkId:              k.Id(),
+					LinkData:            k.Data(),
+					Metric:              k.Metric(),
+				}
+				// link data of stub link is network mask
+				if k.Type() == gosnappi.Ospfv2LinkType.STUB {
+					lsa.Prefix = ospfv2Prefix(k.Id(), k.Data())
+				}
+				lsas = append(lsas, lsa)
+			}
+		}
+		for _, l := range v.NetworkLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaNetwork,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Prefix:              ospfv2Prefix(l.Header().LsaId(), l.NetworkMask()),
+				Extra:               strings.Join(l.NeighborRouterIds(), ","),
+			})
+		}
+		for _, l := range v.NetworkSummaryLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaNetworkSummary,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Prefix:              ospfv2Prefix(l.Header().LsaId(), l.NetworkMask()),
+				Metric:              l.Metric(),
+			})
+		}
+		for _, l := range v.SummaryAsLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaSummaryAs,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Prefix:              ospfv2Prefix(l.Header().LsaId(), l.NetworkMask()),
+				Metric:              l.Metric(),
+			})
+		}
+		for _, l := range v.ExternalAsLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaExternalAs,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Prefix:              ospfv2Prefix(l.Header().LsaId(), l.NetworkMask()),
+				Metric:              l.Metric(),
+				MetricType:          l.MetricType(),
+			})
+		}
+		for _, l := range v.NssaLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaNssa,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Prefix:              ospfv2Prefix(l.Header().LsaId(), l.NetworkMask()),
+				Metric:              l.Metric(),
+				MetricType:          l.MetricType(),
+				ForwardingAddress:   l.ForwardingAddress(),
+			})
+		}
+		for _, l := range v.OpaqueLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaOpaque,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Extra:               string(l.Type()),
+			})
+		}
+	}
+
+	return lsas
+}
+
+func FlattenOspfv3Lsas(states []gosnappi.Ospfv3LsaState) []OspfLsa {
+	lsas := []OspfLsa{}
+
+	for _, v := range states {
+		name := v.RouterName()
+		for _, l := range v.RouterLsas().Items() {
+			for _, k := range l.Links().Items() {
+				lsas = append(lsas, OspfLsa{
+					RouterName:          name,
+					Type:                OspfLsaRouter,
+					LsaId:               l.Header().LsaId(),
+					AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+					LinkType:            string(k.Type()),
+					LinkId:              l.NeighborRouterId(),
+					Metric:              k.Metric(),
+				})
+			}
+		}
+		for _, l := range v.NetworkLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaNetwork,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Extra:               strings.Join(l.AttachedRouterIds(), ","),
+			})
+		}
+		for _, l := range v.InterAreaPrefixLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaInterAreaPrefix,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Prefix:              maskedPrefixKey(l.AddressPrefix(), l.PrefixLength()),
+				Metric:              l.Metric(),
+			})
+		}
+		for _, l := range v.InterAreaRouterLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaInterAreaRouter,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				LinkId:              l.DestinationRouterId(),
+				Metric:              l.Metric(),
+			})
+		}
+		for _, l := range v.ExternalAsLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaExternalAs,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Prefix:              maskedPrefixKey(l.AddressPrefix(), l.PrefixLength()),
+				Metric:              l.Metric(),
+				ForwardingAddress:   l.ForwardingAddress(),
+			})
+		}
+		for _, l := range v.NssaLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaNssa,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Prefix:              maskedPrefixKey(l.AddressPrefix(), l.PrefixLength()),
+				Metric:              l.Metric(),
+				ForwardingAddress:   l.ForwardingAddress(),
+			})
+		}
+		for _, l := range v.LinkLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaLink,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Prefix:              maskedPrefixKey(l.AddressPrefix(), l.PrefixLength()),
+				Extra:               l.LinkLocalAddress(),
+			})
+		}
+		for _, l := range v.IntraAreaPrefixLsas().Items() {
+			lsas = append(lsas, OspfLsa{
+				RouterName:          name,
+				Type:                OspfLsaIntraAreaPrefix,
+				LsaId:               l.Header().LsaId(),
+				AdvertisingRouterId: l.Header().AdvertisingRouterId(),
+				Prefix:              maskedPrefixKey(l.AddressPrefix(), l.PrefixLength()),
+				Metric:              l.Metric(),
+			})
+		}
+	}
+
+	return lsas
+}
+
+func ospfLsaRows(lsas []OspfLsa) [][]interface{} {
+	rows := [][]interface{}{}
+	for _, l := range lsas {
+		rows = append(rows, []interface{}{
+			l.RouterName,
+			l.Type,
+			l.LsaId,
+			l.AdvertisingRouterId,
+			l.LinkType,
+			l.LinkId,
+			l.LinkData,
+			l.Prefix,
+			l.Metric,
+			l.Extra,
+		})
+	}
+	return rows
+}
+
+// OspfLsaMatch describes an expected LSA; empty or zero fields are not checked
+type OspfLsaMatch struct {
+	RouterName          string
+	Type                OspfLsaType
+	LsaId               string
+	AdvertisingRouterId string
+	LinkType            string
+	LinkId              string
+	LinkData            string
+	// prefix in address/length form, host bits are ignored
+	Prefix            string
+	Metric            uint32
+	MetricType        uint32
+	ForwardingAddress string
+}
+
+func (m *OspfLsaMatch) String() string {
+	out := []string{}
+	for _, f := range [][2]string{
+		{"router", m.RouterName},
+		{"type", string(m.Type)},
+		{"lsaId", m.LsaId},
+		{"advRouterId", m.AdvertisingRouterId},
+		{"linkType", m.LinkType},
+		{"linkId", m.LinkId},
+		{"linkData", m.LinkData},
+		{"prefix", m.Prefix},
+		{"forwardingAddress", m.ForwardingAddress},
+	} {
+		if f[1] != "" {
+			out = append(out, fmt.Sprintf("%s=%s", f[0], f[1]))
+		}
+	}
+	if m.Metric != 0 {
+		out = append(out, fmt.Sprintf("metric=%d", m.Metric))
+	}
+	if m.MetricType != 0 {
+		out = append(out, fmt.Sprintf("metricType=%d", m.MetricType))
+	}
+	return strings.Join(out, " ")
+}
+
+func (m *OspfLsaMatch) matches(l OspfLsa) bool {
+	prefix := m.Prefix
+	if prefix != "" {
+		if addr, length, ok := strings.Cut(prefix, "/"); ok {
+			if n, err := strconv.ParseUint(length, 10, 32); err == nil {
+				prefix = maskedPrefixKey(addr, uint32(n))
+			}
+		}
+	}
+
+	return (m.RouterName == "" || m.RouterName == l.RouterName) &&
+		(m.Type == "" || m.Type == l.Type) &&
+		(m.LsaId == "" || m.LsaId == l.LsaId) &&
+		(m.AdvertisingRouterId == "" || m.AdvertisingRouterId == l.AdvertisingRouterId) &&
+		(m.LinkType == "" || m.LinkType == l.LinkType) &&
+		(m.LinkId == "" || m.LinkId == l.LinkId) &&
+		(m.LinkData == "" || m.LinkData == l.LinkData) &&
+		(prefix == "" || prefix == l.Prefix) &&
+		(m.Metric == 0 || m.Metric == l.Metric) &&
+		(m.MetricType == 0 || m.MetricType == l.MetricType) &&
+		(m.ForwardingAddress == "" || net.ParseIP(m.ForwardingAddress).Equal(net.ParseIP(l.ForwardingAddress)))
+}
+
+// Count returns number of LSAs (or router LSA links) matching m
+func (m *OspfLsaMatch) Count(lsas []OspfLsa) int {
+	count := 0
+	for _, l := range lsas {
+		if m.matches(l) {
+			count += 1
+		}
+	}
+	return count
+}
+
+// Check ensures that exactly expCount LSAs match m
+func (m *OspfLsaMatch) Check(lsas []OspfLsa, expCount int) error {
+	if actCount := m.Count(lsas); actCount != expCount {
+		return fmt.Errorf("LSA {%s}: expCount %d != actCount %d", m, expCount, actCount)
+	}
+	return nil
+}
+
+func (m *OspfLsaMatch) Has(t *testing.T, lsas []OspfLsa, expCount int) bool {
+	if err := m.Check(lsas, expCount); err != nil {
+		t.Logf("WARNING: %v\n", err)
+		return false
+	}
+
+	return true
+}
+
+func (m *OspfLsaMatch) Validate(t *testing.T, lsas []OspfLsa, expCount int) {
+	if err := m.Check(lsas, expCount); err != nil {
+		t.Fatalf("ERROR: %v\n", err)
+	}
+}
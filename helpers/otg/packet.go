@@ -0,0 +1,690 @@
+package otg
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+// PacketHeaderSpec describes a flow sent from first to second OTG port, where
+// each header is expressed as OTG flow header in YAML, e.g.
+//
+//	choice: udp
+//	udp:
+//	  src_port:
+//	    choice: increment
+//	    increment: {start: 5000, step: 2, count: 10}
+type PacketHeaderSpec struct {
+	Name     string
+	PktRate  uint64
+	PktCount uint32
+	PktSize  uint32
+	// outermost header first
+	Headers []string
+}
+
+// packetFieldLayout is offset and length (in bits) of a field from start of
+// its header
+type packetFieldLayout struct {
+	offset int
+	length int
+}
+
+type packetHeaderLayout struct {
+	// header length in bytes
+	length int
+	// keyed by field name as in OTG flow header, where fields nested under a
+	// choice (e.g. ipv4 priority) are joined using '.'
+	fields map[string]packetFieldLayout
+}
+
+var packetHeaderLayouts = map[string]packetHeaderLayout{
+	"ethernet": {14, map[string]packetFieldLayout{
+		"dst":        {0, 48},
+		"src":        {48, 48},
+		"ether_type": {96, 16},
+	}},
+	"ipv4": {20, map[string]packetFieldLayout{
+		"version":                  {0, 4},
+		"header_length":            {4, 4},
+		"priority.raw":             {8, 8},
+		"priority.dscp.phb":        {8, 6},
+		"priority.dscp.ecn":        {14, 2},
+		"priority.tos.precedence":  {8, 3},
+		"priority.tos.delay":       {11, 1},
+		"priority.tos.throughput":  {12, 1},
+		"priority.tos.reliability": {13, 1},
+		"priority.tos.monetary":    {14, 1},
+		"priority.tos.unused":      {15, 1},
+		"total_length":             {16, 16},
+		"identification":           {32, 16},
+		"reserved":                 {48, 1},
+		"dont_fragment":            {49, 1},
+		"more_fragments":           {50, 1},
+		"fragment_offset":          {51, 13},
+		"time_to_live":             {64, 8},
+		"protocol":                 {72, 8},
+		"header_checksum":          {80, 16},
+		"src":                      {96, 32},
+		"dst":                      {128, 32},
+	}},
+	"ipv6": {40, map[string]packetFieldLayout{
+		"version":        {0, 4},
+		"traffic_class":  {4, 8},
+		"flow_label":     {12, 20},
+		"payload_length": {32, 16},
+		"next_header":    {48, 8},
+		"hop_limit":      {56, 8},
+		"src":            {64, 128},
+		"dst":            {192, 128},
+	}},
+	"udp": {8, map[string]packetFieldLayout{
+		"src_port": {0, 16},
+		"dst_port": {16, 16},
+		"length":   {32, 16},
+		"checksum": {48, 16},
+	}},
+	"tcp": {20, map[string]packetFieldLayout{
+		"src_port":    {0, 16},
+		"dst_port":    {16, 16},
+		"seq_num":     {32, 32},
+		"ack_num":     {64, 32},
+		"data_offset": {96, 4},
+		"ecn_ns":      {103, 1},
+		"ecn_cwr":     {104, 1},
+		"ecn_echo":    {105, 1},
+		"ctl_urg":     {106, 1},
+		"ctl_ack":     {107, 1},
+		"ctl_psh":     {108, 1},
+		"ctl_rst":     {109, 1},
+		"ctl_syn":     {110, 1},
+		"ctl_fin":     {111, 1},
+		"window":      {112, 16},
+		"checksum":    {128, 16},
+	}},
+	"vlan": {4, map[string]packetFieldLayout{
+		"priority": {0, 3},
+		"cfi":      {3, 1},
+		"id":       {4, 12},
+		// ether type of the next header
+		"tpid": {16, 16},
+	}},
+	"mpls": {4, map[string]packetFieldLayout{
+		"label":           {0, 20},
+		"traffic_class":   {20, 3},
+		"bottom_of_stack": {23, 1},
+		"time_to_live":    {24, 8},
+	}},
+	// checksum and reserved1 are present only when checksum_present is set
+	"gre": {8, map[string]packetFieldLayout{
+		"checksum_present": {0, 1},
+		"reserved0":        {1, 12},
+		"version":          {13, 3},
+		"protocol":         {16, 16},
+		"checksum":         {32, 16},
+		"reserved1":        {48, 16},
+	}},
+	// sequence number, N-PDU number and next extension header type are present
+	// only when any of e_flag, s_flag and pn_flag is set
+	"gtpv1": {12, map[string]packetFieldLayout{
+		"version":                    {0, 3},
+		"protocol_type":              {3, 1},
+		"reserved":                   {4, 1},
+		"e_flag":                     {5, 1},
+		"s_flag":                     {6, 1},
+		"pn_flag":                    {7, 1},
+		"message_type":               {8, 8},
+		"message_length":             {16, 16},
+		"teid":                       {32, 32},
+		"squence_number":             {64, 16},
+		"n_pdu_number":               {80, 8},
+		"next_extension_header_type": {88, 8},
+	}},
+	"arp": {28, map[string]packetFieldLayout{
+		"hardware_type":        {0, 16},
+		"protocol_type":        {16, 16},
+		"hardware_length":      {32, 8},
+		"protocol_length":      {40, 8},
+		"operation":            {48, 16},
+		"sender_hardware_addr": {64, 48},
+		"sender_protocol_addr": {112, 32},
+		"target_hardware_addr": {144, 48},
+		"target_protocol_addr": {192, 32},
+	}},
+	"icmp": {8, map[string]packetFieldLayout{
+		"echo.type":            {0, 8},
+		"echo.code":            {8, 8},
+		"echo.checksum":        {16, 16},
+		"echo.identifier":      {32, 16},
+		"echo.sequence_number": {48, 16},
+	}},
+	"icmpv6": {8, map[string]packetFieldLayout{
+		"echo.type":            {0, 8},
+		"echo.code":            {8, 8},
+		"echo.checksum":        {16, 16},
+		"echo.identifier":      {32, 16},
+		"echo.sequence_number": {48, 16},
+	}},
+	"igmpv1": {8, map[string]packetFieldLayout{
+		"version":       {0, 4},
+		"type":          {4, 4},
+		"unused":        {8, 8},
+		"checksum":      {16, 16},
+		"group_address": {32, 32},
+	}},
+	// length of custom header is that of its bytes
+	"custom": {0, map[string]packetFieldLayout{}},
+	"vxlan": {8, map[string]packetFieldLayout{
+		"flags":     {0, 8},
+		"reserved0": {8, 24},
+		"vni":       {32, 24},
+		"reserved1": {56, 8},
+	}},
+}
+
+// packetNextHeaderValues holds value of ether type / protocol / next header
+// field identifying the next header
+var packetNextHeaderValues = map[string]map[string]uint64{
+	"ethernet": {"ipv4": 0x0800, "ipv6": 0x86dd, "vlan": 0x8100, "mpls": 0x8847, "arp": 0x0806},
+	"vlan":     {"ipv4": 0x0800, "ipv6": 0x86dd, "vlan": 0x8100, "mpls": 0x8847, "arp": 0x0806},
+	"ipv4":     {"ipv4": 4, "ipv6": 41, "tcp": 6, "udp": 17, "gre": 47, "icmp": 1, "igmpv1": 2},
+	"ipv6":     {"ipv4": 4, "ipv6": 41, "tcp": 6, "udp": 17, "gre": 47, "icmpv6": 58},
+	"gre":      {"ipv4": 0x0800, "ipv6": 0x86dd, "ethernet": 0x6558},
+}
+
+// packetFlagSet returns true if pattern of a flag is set to fixed value 1
+func packetFlagSet(p map[string]interface{}) bool {
+	return p != nil && p["choice"] == "value" && p["value"] == float64(1)
+}
+
+// packetHeaderLength returns length (in bytes) of header including optional
+// fields present as per its patterns
+func packetHeaderLength(header string, obj map[string]interface{}, patterns map[string]map[string]interface{}) int {
+	switch header {
+	case "gre":
+		if !packetFlagSet(patterns["checksum_present"]) {
+			return 4
+		}
+	case "gtpv1":
+		if !packetFlagSet(patterns["e_flag"]) && !packetFlagSet(patterns["s_flag"]) && !packetFlagSet(patterns["pn_flag"]) {
+			return 8
+		}
+	case "custom":
+		b, _ := obj["bytes"].(string)
+		return len(b) / 2
+	}
+
+	return packetHeaderLayouts[header].length
+}
+
+// packetAutoValue returns value of a field with auto choice, where offset is
+// byte offset of its header and next is choice of the next header
+func packetAutoValue(header string, field string, offset int, next string, frameSize int) *big.Int {
+	// frame size includes 4 bytes of FCS
+	switch header + "." + field {
+	case "ethernet.ether_type", "vlan.tpid", "ipv4.protocol", "ipv6.next_header", "gre.protocol":
+		if v, ok := packetNextHeaderValues[header][next]; ok {
+			return new(big.Int).SetUint64(v)
+		}
+	case "mpls.bottom_of_stack":
+		// set only for the last label of stack
+		if next == "mpls" {
+			return big.NewInt(0)
+		}
+		return big.NewInt(1)
+	case "ipv4.header_length":
+		return big.NewInt(5)
+	case "ipv4.total_length", "udp.length":
+		return big.NewInt(int64(frameSize - 4 - offset))
+	case "ipv6.payload_length":
+		return big.NewInt(int64(frameSize - 4 - offset - 40))
+	case "gtpv1.message_length":
+		// excludes mandatory part of gtpv1 header
+		return big.NewInt(int64(frameSize - 4 - offset - 8))
+	}
+
+	return nil
+}
+
+// packetSumFunc returns function computing checksum of a field from packet
+// data, or nil if checksum of the field is not known; offset is byte offset of
+// the header and prevOffset is that of the previous (IP) header
+func packetSumFunc(header string, field string, offset int, prev string, prevOffset int) func([]byte) (uint16, error) {
+	if header == "ipv4" && field == "header_checksum" {
+		return func(data []byte) (uint16, error) {
+			return ipv4HeaderChecksum(data, offset)
+		}
+	}
+	if prev != "ipv4" && prev != "ipv6" {
+		return nil
+	}
+
+	at := offset + packetHeaderLayouts[header].fields[field].offset/8
+	protocol := uint8(0)
+	switch header + "." + field {
+	case "udp.checksum":
+		protocol = 17
+	case "tcp.checksum":
+		protocol = 6
+	case "icmpv6.echo.checksum":
+		protocol = 58
+	case "icmp.echo.checksum", "igmpv1.checksum", "gre.checksum":
+		// not covering pseudo header
+	default:
+		return nil
+	}
+
+	return func(data []byte) (uint16, error) {
+		return upperLayerChecksum(data, prevOffset, offset, at, protocol)
+	}
+}
+
+type packetField struct {
+	// header choice, its index in stack and field name, e.g. udp[2].src_port
+	name   string
+	offset int
+	length int
+	// OTG pattern of the field, or nil if value is auto computed
+	pattern map[string]interface{}
+	auto    *big.Int
+	// computes checksum from packet data, or nil if checksum is not known
+	sum func([]byte) (uint16, error)
+}
+
+// packetBigInt converts value of a pattern (number, MAC, IPv4 or IPv6) to
+// big.Int
+func packetBigInt(v interface{}) (*big.Int, error) {
+	switch val := v.(type) {
+	case float64:
+		return new(big.Int).SetUint64(uint64(val)), nil
+	case string:
+		if hw, err := net.ParseMAC(val); err == nil {
+			return new(big.Int).SetBytes(hw), nil
+		}
+		if ip := net.ParseIP(val); ip != nil {
+			if v4 := ip.To4(); v4 != nil && !strings.Contains(val, ":") {
+				return new(big.Int).SetBytes(v4), nil
+			}
+			return new(big.Int).SetBytes(ip.To16()), nil
+		}
+		// decimal or hex prefixed with 0x
+		if n, ok := new(big.Int).SetString(val, 0); ok {
+			return n, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not parse pattern value %v", v)
+}
+
+// packetBits returns value of length bits starting at bit offset of data
+func packetBits(data []byte, offset int, length int) (*big.Int, error) {
+	start, end := offset/8, (offset+length+7)/8
+	if end > len(data) {
+		return nil, fmt.Errorf("bits [%d, %d) not in range [0, %d)", offset, offset+length, 8*len(data))
+	}
+
+	v := new(big.Int).SetBytes(data[start:end])
+	v.Rsh(v, uint(8*end-offset-length))
+	return v.And(v, packetMask(length)), nil
+}
+
+func packetMask(length int) *big.Int {
+	m := new(big.Int).Lsh(big.NewInt(1), uint(length))
+	return m.Sub(m, big.NewInt(1))
+}
+
+// expected returns value of field in index-th packet of the flow, or nil with
+// range [min, max] when value is random
+func (f *packetField) expected(index int) (*big.Int, *[2]*big.Int, error) {
+	if f.pattern == nil {
+		return f.auto, nil, nil
+	}
+
+	choice, _ := f.pattern["choice"].(string)
+	switch choice {
+	case "value", "custom":
+		v, err := packetBigInt(f.pattern[choice])
+		return v, nil, err
+	case "values":
+		values, _ := f.pattern["values"].([]interface{})
+		if len(values) == 0 {
+			return nil, nil, fmt.Errorf("%s: no values", f.name)
+		}
+		v, err := packetBigInt(values[index%len(values)])
+		return v, nil, err
+	case "increment", "decrement":
+		counter, _ := f.pattern[choice].(map[string]interface{})
+		start, step, count := big.NewInt(0), big.NewInt(1), 1
+		var err error
+		if s, ok := counter["start"]; ok {
+			if start, err = packetBigInt(s); err != nil {
+				return nil, nil, err
+			}
+		}
+		if s, ok := counter["step"]; ok {
+			if step, err = packetBigInt(s); err != nil {
+				return nil, nil, err
+			}
+		}
+		if c, ok := counter["count"].(float64); ok && c > 0 {
+			count = int(c)
+		}
+		delta := new(big.Int).Mul(step, big.NewInt(int64(index%count)))
+		if choice == "increment" {
+			start.Add(start, delta)
+		} else {
+			start.Sub(start, delta)
+		}
+		// wraps around within field length
+		mod := new(big.Int).Lsh(big.NewInt(1), uint(f.length))
+		return start.Mod(start, mod), nil, nil
+	case "random":
+		random, _ := f.pattern["random"].(map[string]interface{})
+		r := [2]*big.Int{big.NewInt(0), packetMask(f.length)}
+		for i, k := range []string{"min", "max"} {
+			if s, ok := random[k]; ok {
+				v, err := packetBigInt(s)
+				if err != nil {
+					return nil, nil, err
+				}
+				r[i] = v
+			}
+		}
+		return nil, &r, nil
+	}
+
+	// auto and generated values are not validated unless known
+	return f.auto, nil, nil
+}
+
+// checkSum validates checksum field in data against the one computed, where
+// checksum generated as bad is expected to differ
+func (f *packetField) checkSum(data []byte) error {
+	sum, err := f.sum(data)
+	if err != nil {
+		return fmt.Errorf("%s: %v", f.name, err)
+	}
+	exp := big.NewInt(int64(sum))
+	act, err := packetBits(data, f.offset, f.length)
+	if err != nil {
+		return fmt.Errorf("%s: %v", f.name, err)
+	}
+
+	if f.pattern != nil && f.pattern["generated"] == "bad" {
+		if act.Cmp(exp) == 0 {
+			return fmt.Errorf("%s: actual 0x%x is not bad checksum", f.name, act)
+		}
+		return nil
+	}
+	if act.Cmp(exp) != 0 {
+		return fmt.Errorf("%s: computed 0x%x != actual 0x%x", f.name, exp, act)
+	}
+
+	return nil
+}
+
+// check validates field in data of index-th packet of the flow
+func (f *packetField) check(data []byte, index int) error {
+	if f.sum != nil && (f.pattern == nil || f.pattern["choice"] == "generated") {
+		return f.checkSum(data)
+	}
+
+	exp, r, err := f.expected(index)
+	if err != nil {
+		return err
+	}
+	if exp == nil && r == nil {
+		return nil
+	}
+
+	act, err := packetBits(data, f.offset, f.length)
+	if err != nil {
+		return fmt.Errorf("%s: %v", f.name, err)
+	}
+	if r != nil {
+		if act.Cmp(r[0]) < 0 || act.Cmp(r[1]) > 0 {
+			return fmt.Errorf("%s: actual 0x%x not in range [0x%x, 0x%x]", f.name, act, r[0], r[1])
+		}
+		return nil
+	}
+	if act.Cmp(exp) != 0 {
+		return fmt.Errorf("%s: expected 0x%x != actual 0x%x", f.name, exp, act)
+	}
+
+	return nil
+}
+
+// packetPatterns flattens patterns of a header, where fields nested under a
+// choice are joined using '.'
+func packetPatterns(prefix string, obj map[string]interface{}, patterns map[string]map[string]interface{}) {
+	for k, v := range obj {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := k
+		if prefix != "" {
+			name = prefix + "." + k
+		}
+		choice, _ := m["choice"].(string)
+		switch choice {
+		case "value", "values", "increment", "decrement", "random", "auto", "generated", "custom":
+			patterns[name] = m
+		default:
+			packetPatterns(name, m, patterns)
+		}
+	}
+}
+
+// packetFields returns fields of all headers of flow along with their bit
+// offsets in frames of size frameSize
+func packetFields(flow gosnappi.Flow, frameSize int) ([]packetField, error) {
+	headers := flow.Packet().Items()
+	fields := []packetField{}
+	offset, prev, prevOffset := 0, "", 0
+	for i, h := range headers {
+		header := string(h.Choice())
+		layout, ok := packetHeaderLayouts[header]
+		if !ok {
+			return nil, fmt.Errorf("header %s not supported", header)
+		}
+		next := ""
+		if i+1 < len(headers) {
+			next = string(headers[i+1].Choice())
+		}
+
+		js, err := h.Marshal().ToJson()
+		if err != nil {
+			return nil, err
+		}
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(js), &obj); err != nil {
+			return nil, err
+		}
+		hObj, _ := obj[header].(map[string]interface{})
+		patterns := map[string]map[string]interface{}{}
+		packetPatterns("", hObj, patterns)
+		for name := range patterns {
+			if _, ok := layout.fields[name]; !ok {
+				return nil, fmt.Errorf("field %s of header %s not supported", name, header)
+			}
+		}
+		length := packetHeaderLength(header, hObj, patterns)
+
+		if b, ok := hObj["bytes"].(string); ok && header == "custom" {
+			fields = append(fields, packetField{
+				name:    fmt.Sprintf("%s[%d].bytes", header, i),
+				offset:  8 * offset,
+				length:  8 * length,
+				pattern: map[string]interface{}{"choice": "value", "value": "0x" + b},
+			})
+		}
+
+		for name, fl := range layout.fields {
+			// optional fields not present
+			if fl.offset >= 8*length {
+				continue
+			}
+			p, hasPattern := patterns[name]
+			auto := packetAutoValue(header, name, offset, next, frameSize)
+			sum := packetSumFunc(header, name, offset, prev, prevOffset)
+			if !hasPattern && auto == nil && sum == nil {
+				continue
+			}
+			fields = append(fields, packetField{
+				name:    fmt.Sprintf("%s[%d].%s", header, i, name),
+				offset:  8*offset + fl.offset,
+				length:  fl.length,
+				pattern: p,
+				auto:    auto,
+				sum:     sum,
+			})
+		}
+		prev, prevOffset = header, offset
+		offset += length
+	}
+
+	sort.Slice(fields, func(i, j int) bool { return fields[i].offset < fields[j].offset })
+	return fields, nil
+}
+
+// ValidatePacketHeaders validates every header field of flow in captured
+// packets, where packets not matching fixed ethernet addresses of flow are
+// ignored; returns number of validated packets
+func (o *OtgApi) ValidatePacketHeaders(cPackets *CapturedPackets, flow gosnappi.Flow) int {
+	t := o.Testing()
+
+	// length fields depend on size of each frame unless fixed
+	fixed := flow.Size().Choice() == gosnappi.FlowSizeChoice.FIXED
+	frameSize := 0
+	if fixed {
+		frameSize = int(flow.Size().Fixed())
+	}
+	fields, err := packetFields(flow, frameSize)
+	if err != nil {
+		t.Fatalf("ERROR: Could not get fields of flow %s: %v\n", flow.Name(), err)
+	}
+	sizeFields := map[int][]packetField{frameSize: fields}
+
+	// ethernet addresses with fixed value identify packets of the flow
+	filters := []packetField{}
+	for _, f := range fields {
+		if strings.HasPrefix(f.name, "ethernet[0].") && f.pattern != nil && f.pattern["choice"] == "value" {
+			filters = append(filters, f)
+		}
+	}
+
+	count := 0
+	for i, p := range cPackets.Packets {
+		matched := true
+		for _, f := range filters {
+			if f.check(p.Data, count) != nil {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		if fixed {
+			cPackets.ValidateSize(t, i, frameSize)
+		}
+		pFields, ok := sizeFields[len(p.Data)]
+		if !ok {
+			if pFields, err = packetFields(flow, len(p.Data)); err != nil {
+				t.Fatalf("ERROR: Could not get fields of flow %s: %v\n", flow.Name(), err)
+			}
+			sizeFields[len(p.Data)] = pFields
+		}
+		for _, f := range pFields {
+			if err := f.check(p.Data, count); err != nil {
+				t.Fatalf("ERROR: flow %s packet %d: %v\n", flow.Name(), i, err)
+			}
+		}
+		count += 1
+	}
+
+	return count
+}
+
+// PacketHeaderConfig returns config with a single flow from first to second
+// OTG port as per spec
+func (o *OtgApi) PacketHeaderConfig(spec PacketHeaderSpec) gosnappi.Config {
+	t := o.Testing()
+
+	c := gosnappi.NewConfig()
+	p1 := c.Ports().Add().SetName("p1").SetLocation(o.TestConfig().OtgPorts[0])
+	p2 := c.Ports().Add().SetName("p2").SetLocation(o.TestConfig().OtgPorts[1])
+
+	c.Layer1().Add().
+		SetName("ly").
+		SetPortNames([]string{p1.Name(), p2.Name()}).
+		SetSpeed(gosnappi.Layer1SpeedEnum(o.TestConfig().OtgSpeed))
+
+	if o.TestConfig().OtgCaptureCheck {
+		c.Captures().Add().
+			SetName("ca").
+			SetPortNames([]string{p2.Name()}).
+			SetFormat(gosnappi.CaptureFormat.PCAP)
+	}
+
+	f1 := c.Flows().Add().SetName("f1")
+	f1.TxRx().Port().
+		SetTxName(p1.Name()).
+		SetRxNames([]string{p2.Name()})
+	f1.Duration().FixedPackets().SetPackets(spec.PktCount)
+	f1.Rate().SetPps(spec.PktRate)
+	f1.Size().SetFixed(spec.PktSize)
+	f1.Metrics().SetEnable(true)
+
+	for i, h := range spec.Headers {
+		if err := f1.Packet().Add().Unmarshal().FromYaml(h); err != nil {
+			t.Fatalf("ERROR: Could not load header %d of %s: %v\n", i, spec.Name, err)
+		}
+	}
+
+	t.Logf("Config:\n%v\n", c)
+	return c
+}
+
+// RunPacketHeaderTest sends flow as per spec and validates header fields of
+// each packet captured on second OTG port
+func (o *OtgApi) RunPacketHeaderTest(spec PacketHeaderSpec) {
+	t := o.Testing()
+	defer o.Timer(time.Now(), "RunPacketHeaderTest")
+
+	c := o.PacketHeaderConfig(spec)
+
+	o.SetConfig(c)
+
+	o.StartCapture()
+	o.StartTransmit()
+
+	o.WaitFor(
+		func() bool {
+			m := o.GetFlowMetrics()[0]
+			return m.Transmit() == gosnappi.FlowMetricTransmit.STOPPED &&
+				m.FramesTx() == uint64(spec.PktCount) &&
+				m.FramesRx() == uint64(spec.PktCount)
+		},
+		&WaitForOpts{FnName: "WaitForFlowMetrics"},
+	)
+
+	o.StopCapture()
+
+	if !o.TestConfig().OtgCaptureCheck {
+		return
+	}
+
+	cPackets := o.GetCapture(c.Ports().Items()[1].Name())
+	if count := o.ValidatePacketHeaders(cPackets, c.Flows().Items()[0]); count != int(spec.PktCount) {
+		t.Fatalf("ERROR: expCount %d != actCount %d\n", spec.PktCount, count)
+	}
+}
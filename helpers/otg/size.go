@@ -0,0 +1,159 @@
+package otg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/open-traffic-generator/conformance/helpers/table"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+// predefinedFrameSizeWeights holds <size, weight> pairs of predefined frame
+// size distributions as documented in OTG model
+var predefinedFrameSizeWeights = map[gosnappi.FlowSizeWeightPairsPredefinedEnum]map[int]float64{
+	gosnappi.FlowSizeWeightPairsPredefined.IMIX:          {64: 7, 570: 4, 1518: 1},
+	gosnappi.FlowSizeWeightPairsPredefined.IPSEC_IMIX:    {90: 58.67, 92: 2, 594: 23.66, 1418: 15.67},
+	gosnappi.FlowSizeWeightPairsPredefined.IPV6_IMIX:     {60: 58.67, 496: 2, 594: 23.66, 1518: 15.67},
+	gosnappi.FlowSizeWeightPairsPredefined.STANDARD_IMIX: {58: 58.67, 62: 2, 594: 23.66, 1518: 15.67},
+	gosnappi.FlowSizeWeightPairsPredefined.TCP_IMIX:      {90: 58.67, 92: 2, 594: 23.66, 1518: 15.67},
+}
+
+// FrameSizeRatios returns fraction of frames (adding up to 1) expected for
+// each frame size of flow, or nil when sizes are random
+func (o *OtgApi) FrameSizeRatios(size gosnappi.FlowSize) map[int]float64 {
+	t := o.Testing()
+
+	weights := map[int]float64{}
+	switch size.Choice() {
+	case gosnappi.FlowSizeChoice.FIXED:
+		weights[int(size.Fixed())] = 1
+	case gosnappi.FlowSizeChoice.INCREMENT:
+		inc := size.Increment()
+		if inc.Step() == 0 || inc.End() < inc.Start() {
+			t.Fatalf("ERROR: Invalid frame size increment %d to %d by %d\n", inc.Start(), inc.End(), inc.Step())
+		}
+		for s := inc.Start(); s <= inc.End(); s += inc.Step() {
+			weights[int(s)] = 1
+		}
+	case gosnappi.FlowSizeChoice.RANDOM:
+		return nil
+	case gosnappi.FlowSizeChoice.WEIGHT_PAIRS:
+		wp := size.WeightPairs()
+		if wp.Choice() == gosnappi.FlowSizeWeightPairsChoice.CUSTOM {
+			for _, p := range wp.Custom().Items() {
+				weights[int(p.Size())] += float64(p.Weight())
+			}
+		} else {
+			for s, w := range predefinedFrameSizeWeights[wp.Predefined()] {
+				weights[s] = w
+			}
+		}
+	default:
+		t.Fatalf("ERROR: Frame size %s not supported\n", size.Choice())
+	}
+
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		t.Fatalf("ERROR: No frame sizes with non-zero weight in %v\n", size)
+	}
+	for s := range weights {
+		weights[s] /= total
+	}
+
+	return weights
+}
+
+// AvgFrameSize returns average size of frames of flow
+func (o *OtgApi) AvgFrameSize(size gosnappi.FlowSize) float64 {
+	if size.Choice() == gosnappi.FlowSizeChoice.RANDOM {
+		return float64(size.Random().Min()+size.Random().Max()) / 2
+	}
+
+	avg := 0.0
+	for s, r := range o.FrameSizeRatios(size) {
+		avg += float64(s) * r
+	}
+	return avg
+}
+
+// ValidateFrameSizes validates sizes of packets with ethernet source srcMac in
+// capture against frame size distribution of flow, where fraction of frames
+// of each size (or average size, when sizes are random) may deviate from
+// expected by tolerance; returns number of validated packets
+func (o *OtgApi) ValidateFrameSizes(cPackets *CapturedPackets, size gosnappi.FlowSize, srcMac string, tolerance float64) int {
+	t := o.Testing()
+
+	mac := o.MacAddrToBytes(srcMac)
+	counts := map[int]int{}
+	count := 0
+	total := 0
+	for i := range cPackets.Packets {
+		if !cPackets.HasField(t, "ethernet src", i, 6, mac) {
+			continue
+		}
+		s := len(cPackets.Packets[i].Data)
+		counts[s] += 1
+		count += 1
+		total += s
+	}
+	if count == 0 {
+		t.Fatalf("ERROR: No packets with ethernet src %s in capture\n", srcMac)
+	}
+
+	ratios := o.FrameSizeRatios(size)
+
+	tb := table.NewTable(
+		"Frame Sizes",
+		[]string{"Size", "Frames", "ActRatio", "ExpRatio"},
+		15,
+	)
+	sizes := []int{}
+	for s := range counts {
+		sizes = append(sizes, s)
+	}
+	for s := range ratios {
+		if _, ok := counts[s]; !ok {
+			sizes = append(sizes, s)
+		}
+	}
+	sort.Ints(sizes)
+	for _, s := range sizes {
+		tb.AppendRow([]interface{}{
+			s,
+			counts[s],
+			fmt.Sprintf("%.4f", float64(counts[s])/float64(count)),
+			fmt.Sprintf("%.4f", ratios[s]),
+		})
+	}
+	t.Log(tb.String())
+
+	if ratios == nil {
+		min, max := int(size.Random().Min()), int(size.Random().Max())
+		if sizes[0] < min || sizes[len(sizes)-1] > max {
+			t.Fatalf("ERROR: Frame sizes [%d, %d] not in range [%d, %d]\n", sizes[0], sizes[len(sizes)-1], min, max)
+		}
+		expAvg := o.AvgFrameSize(size)
+		actAvg := float64(total) / float64(count)
+		if math.Abs(actAvg-expAvg) > tolerance*expAvg {
+			t.Fatalf("ERROR: Average frame size %.2f deviates from %.2f by more than %.2f%%\n", actAvg, expAvg, tolerance*100)
+		}
+		return count
+	}
+
+	for _, s := range sizes {
+		r, ok := ratios[s]
+		if !ok {
+			t.Fatalf("ERROR: Unexpected frame size %d in %d frames\n", s, counts[s])
+		}
+		act := float64(counts[s]) / float64(count)
+		if math.Abs(act-r) > tolerance {
+			t.Fatalf("ERROR: Ratio %.4f of frames of size %d deviates from %.4f by more than %.4f\n", act, s, r, tolerance)
+		}
+	}
+
+	return count
+}
@@ -49,6 +49,46 @@ func (o *OtgApi) GetIpv4Neighbors() []gosnappi.Neighborsv4State {
 	return res.Ipv4Neighbors().Items()
 }
 
+func (o *OtgApi) GetIpv6Neighbors() []gosnappi.Neighborsv6State {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting IPv6 Neighbors ...")
+	defer o.Timer(time.Now(), "GetIpv6Neighbors")
+
+	sr := gosnappi.NewStatesRequest()
+	sr.Ipv6Neighbors()
+	res, err := api.GetStates(sr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"IPv6 Neighbors",
+		[]string{
+			"Ethernet Name",
+			"IPv6 Address",
+			"Link Layer Address",
+		},
+		30,
+	)
+
+	for _, v := range res.Ipv6Neighbors().Items() {
+		if v != nil {
+			linkLayerAddress := ""
+			if v.HasLinkLayerAddress() {
+				linkLayerAddress = v.LinkLayerAddress()
+			}
+			tb.AppendRow([]interface{}{
+				v.EthernetName(),
+				v.Ipv6Address(),
+				linkLayerAddress,
+			})
+		}
+	}
+
+	t.Log(tb.String())
+	return res.Ipv6Neighbors().Items()
+}
+
 func (o *OtgApi) GetBgpPrefixes() []gosnappi.BgpPrefixesState {
 	t := o.Testing()
 	api := o.Api()
@@ -155,6 +195,20 @@ func (o *OtgApi) GetIsisLsps() []gosnappi.IsisLspsState {
 		30,
 	)
 
+	tlvTb := table.NewTable(
+		"ISIS LSP TLVs",
+		[]string{
+			"Name",
+			"LSP ID",
+			"PDU Type",
+			"TLV",
+			"Value",
+			"Metric",
+			"SIDs",
+		},
+		25,
+	)
+
 	for _, v := range res.IsisLsps().Items() {
 		for _, w := range v.Lsps().Items() {
 			tb.AppendRow([]interface{}{
@@ -163,10 +217,16 @@ func (o *OtgApi) GetIsisLsps() []gosnappi.IsisLspsState {
 				w.PduType(),
 				w.IsType(),
 			})
+
+			prefix := []interface{}{v.IsisRouterName(), w.LspId(), w.PduType()}
+			for _, r := range isisLspTlvRows(w.Tlvs()) {
+				tlvTb.AppendRow(append(append([]interface{}{}, prefix...), r...))
+			}
 		}
 	}
 
 	t.Log(tb.String())
+	t.Log(tlvTb.String())
 	return res.IsisLsps().Items()
 }
 
@@ -182,7 +242,28 @@ func (o *OtgApi) GetOspfv2Lsas() []gosnappi.Ospfv2LsaState {
 	res, err := api.GetStates(sr)
 	o.LogWrnErr(nil, err, true)
 
-	fmt.Println(res.Marshal().ToJson())
+	tb := table.NewTable(
+		"OSPFv2 LSAs",
+		[]string{
+			"Name",
+			"LSA Type",
+			"LSA ID",
+			"Adv. Router ID",
+			"Link Type",
+			"Link ID",
+			"Link Data",
+			"Prefix",
+			"Metric",
+			"Extra",
+		},
+		18,
+	)
+
+	for _, r := range ospfLsaRows(FlattenOspfv2Lsas(res.Ospfv2Lsas().Items())) {
+		tb.AppendRow(r)
+	}
+
+	t.Log(tb.String())
 	return res.Ospfv2Lsas().Items()
 }
 
@@ -198,7 +279,28 @@ func (o *OtgApi) GetOspfv3Lsas() []gosnappi.Ospfv3LsaState {
 	res, err := api.GetStates(sr)
 	o.LogWrnErr(nil, err, true)
 
-	fmt.Println(res.Marshal().ToJson())
+	tb := table.NewTable(
+		"OSPFv3 LSAs",
+		[]string{
+			"Name",
+			"LSA Type",
+			"LSA ID",
+			"Adv. Router ID",
+			"Link Type",
+			"Link ID",
+			"Link Data",
+			"Prefix",
+			"Metric",
+			"Extra",
+		},
+		18,
+	)
+
+	for _, r := range ospfLsaRows(FlattenOspfv3Lsas(res.Ospfv3Lsas().Items())) {
+		tb.AppendRow(r)
+	}
+
+	t.Log(tb.String())
 	return res.Ospfv3Lsas().Items()
 }
 
@@ -207,7 +309,7 @@ func (o *OtgApi) GetLldpNeighbors() []gosnappi.LldpNeighborsState {
 	api := o.Api()
 
 	t.Log("Getting LLDP Neighbors ...")
-	defer o.Timer(time.Now(), "GetIpv4Neighbors")
+	defer o.Timer(time.Now(), "GetLldpNeighbors")
 
 	sr := gosnappi.NewStatesRequest()
 	sr.LldpNeighbors()
@@ -218,34 +320,346 @@ func (o *OtgApi) GetLldpNeighbors() []gosnappi.LldpNeighborsState {
 		"LLDP Neighbors",
 		[]string{
 			"LLDP Name",
+			"Neighbor ID",
 			"Chassis ID",
 			"Chassis ID Type",
+			"Port ID",
+			"Port ID Type",
+			"Port Description",
 			"System Name",
+			"System Description",
+			"TTL",
+			"Age",
+			"Last Update",
+			"Mgmt Address",
+			"Mgmt Address Type",
+		},
+		20,
+	)
+
+	tlvTb := table.NewTable(
+		"LLDP Neighbor Capabilities And Custom TLVs",
+		[]string{
+			"LLDP Name",
+			"Neighbor ID",
+			"TLV",
+			"Name / OUI",
+			"Enabled / Subtype",
+			"Information",
 		},
 		20,
 	)
 
 	for _, v := range res.LldpNeighbors().Items() {
 		row := []interface{}{v.LldpName()}
-		if v.HasChassisId() {
-			row = append(row, v.ChassisId())
-		} else {
-			row = append(row, "")
-		}
-		if v.HasChassisIdType() {
-			row = append(row, v.ChassisIdType())
-		} else {
-			row = append(row, "")
-		}
-		if v.HasSystemName() {
-			row = append(row, v.SystemName())
-		} else {
-			row = append(row, "")
+		for _, f := range []struct {
+			has   bool
+			value interface{}
+		}{
+			{v.HasNeighborId(), v.NeighborId()},
+			{v.HasChassisId(), v.ChassisId()},
+			{v.HasChassisIdType(), v.ChassisIdType()},
+			{v.HasPortId(), v.PortId()},
+			{v.HasPortIdType(), v.PortIdType()},
+			{v.HasPortDescription(), v.PortDescription()},
+			{v.HasSystemName(), v.SystemName()},
+			{v.HasSystemDescription(), v.SystemDescription()},
+			{v.HasTtl(), v.Ttl()},
+			{v.HasAge(), v.Age()},
+			{v.HasLastUpdate(), v.LastUpdate()},
+			{v.HasManagementAddress(), v.ManagementAddress()},
+			{v.HasManagementAddressType(), v.ManagementAddressType()},
+		} {
+			if f.has {
+				row = append(row, f.value)
+			} else {
+				row = append(row, "")
+			}
 		}
 
 		tb.AppendRow(row)
+
+		for _, c := range v.Capabilities().Items() {
+			tlvTb.AppendRow([]interface{}{
+				v.LldpName(),
+				v.NeighborId(),
+				"capability",
+				c.CapabilityName(),
+				c.CapabilityEnabled(),
+				"",
+			})
+		}
+		for _, c := range v.CustomTlvs().Items() {
+			tlvTb.AppendRow([]interface{}{
+				v.LldpName(),
+				v.NeighborId(),
+				fmt.Sprintf("custom (type %d)", c.CustomType()),
+				c.Oui(),
+				c.OuiSubtype(),
+				c.Information(),
+			})
+		}
 	}
 
 	t.Log(tb.String())
+	t.Log(tlvTb.String())
 	return res.LldpNeighbors().Items()
 }
+
+func (o *OtgApi) GetRsvpLsps() []gosnappi.RsvpLspsState {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting RSVP LSPs ...")
+	defer o.Timer(time.Now(), "GetRsvpLsps")
+
+	sr := gosnappi.NewStatesRequest()
+	sr.RsvpLsps()
+	res, err := api.GetStates(sr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"RSVP LSPs",
+		[]string{
+			"Router Name",
+			"Source",
+			"Destination",
+			"Tunnel ID",
+			"LSP ID",
+			"Session Name",
+			"Label In",
+			"Label Out",
+			"Status",
+			"Last Flap Reason",
+			"Up Time",
+			"RROs",
+		},
+		16,
+	)
+
+	for _, v := range res.RsvpLsps().Items() {
+		if v != nil {
+			for _, l := range v.Ipv4Lsps().Items() {
+				rros := ""
+				for _, r := range l.Rros().Items() {
+					rros += fmt.Sprintf("%s:%d ", r.Address(), r.ReportedLabel())
+				}
+				tb.AppendRow([]interface{}{
+					v.RsvpRouterName(),
+					l.SourceAddress(),
+					l.DestinationAddress(),
+					l.Lsp().TunnelId(),
+					l.Lsp().LspId(),
+					l.Lsp().SessionName(),
+					l.Lsp().LabelIn(),
+					l.Lsp().LabelOut(),
+					l.Lsp().SessionStatus(),
+					l.Lsp().LastFlapReason(),
+					l.Lsp().UpTime(),
+					rros,
+				})
+			}
+		}
+	}
+
+	t.Log(tb.String())
+	return res.RsvpLsps().Items()
+}
+
+func (o *OtgApi) GetDhcpv4Interfaces() []gosnappi.Dhcpv4InterfaceState {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting DHCPv4 Interfaces ...")
+	defer o.Timer(time.Now(), "GetDhcpv4Interfaces")
+
+	sr := gosnappi.NewStatesRequest()
+	sr.Dhcpv4Interfaces()
+	res, err := api.GetStates(sr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"DHCPv4 Interfaces",
+		[]string{
+			"Client Name",
+			"IPv4 Address",
+			"Prefix Length",
+			"Gateway Address",
+			"Lease Time",
+			"Renew Time",
+			"Rebind Time",
+		},
+		18,
+	)
+
+	for _, v := range res.Dhcpv4Interfaces().Items() {
+		if v != nil {
+			tb.AppendRow([]interface{}{
+				v.DhcpClientName(),
+				v.Ipv4Address(),
+				v.PrefixLength(),
+				v.GatewayAddress(),
+				v.LeaseTime(),
+				v.RenewTime(),
+				v.RebindTime(),
+			})
+		}
+	}
+
+	t.Log(tb.String())
+	return res.Dhcpv4Interfaces().Items()
+}
+
+func (o *OtgApi) GetDhcpv4Leases() []gosnappi.Dhcpv4LeasesState {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting DHCPv4 Leases ...")
+	defer o.Timer(time.Now(), "GetDhcpv4Leases")
+
+	sr := gosnappi.NewStatesRequest()
+	sr.Dhcpv4Leases()
+	res, err := api.GetStates(sr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"DHCPv4 Leases",
+		[]string{
+			"Server Name",
+			"Address",
+			"Valid Time",
+			"Preferred Time",
+			"Renew Time",
+			"Rebind Time",
+			"Client ID",
+			"Circuit ID",
+			"Remote ID",
+		},
+		18,
+	)
+
+	for _, v := range res.Dhcpv4Leases().Items() {
+		if v != nil {
+			for _, l := range v.Leases().Items() {
+				tb.AppendRow([]interface{}{
+					v.DhcpServerName(),
+					l.Address(),
+					l.ValidTime(),
+					l.PreferredTime(),
+					l.RenewTime(),
+					l.RebindTime(),
+					l.ClientId(),
+					l.CircuitId(),
+					l.RemoteId(),
+				})
+			}
+		}
+	}
+
+	t.Log(tb.String())
+	return res.Dhcpv4Leases().Items()
+}
+
+func (o *OtgApi) GetDhcpv6Interfaces() []gosnappi.Dhcpv6InterfaceState {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting DHCPv6 Interfaces ...")
+	defer o.Timer(time.Now(), "GetDhcpv6Interfaces")
+
+	sr := gosnappi.NewStatesRequest()
+	sr.Dhcpv6Interfaces()
+	res, err := api.GetStates(sr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"DHCPv6 Interfaces",
+		[]string{
+			"Client Name",
+			"Type",
+			"Address",
+			"Prefix Length",
+			"Gateway",
+			"Lease Time",
+		},
+		25,
+	)
+
+	for _, v := range res.Dhcpv6Interfaces().Items() {
+		if v != nil {
+			for _, a := range v.IaAddresses().Items() {
+				tb.AppendRow([]interface{}{
+					v.DhcpClientName(),
+					"IA",
+					a.Address(),
+					"",
+					a.Gateway(),
+					a.LeaseTime(),
+				})
+			}
+			for _, a := range v.IapdAddresses().Items() {
+				tb.AppendRow([]interface{}{
+					v.DhcpClientName(),
+					"IAPD",
+					a.Address(),
+					a.PrefixLength(),
+					"",
+					a.LeaseTime(),
+				})
+			}
+		}
+	}
+
+	t.Log(tb.String())
+	return res.Dhcpv6Interfaces().Items()
+}
+
+func (o *OtgApi) GetDhcpv6Leases() []gosnappi.Dhcpv6LeasesState {
+	t := o.Testing()
+	api := o.Api()
+
+	t.Log("Getting DHCPv6 Leases ...")
+	defer o.Timer(time.Now(), "GetDhcpv6Leases")
+
+	sr := gosnappi.NewStatesRequest()
+	sr.Dhcpv6Leases()
+	res, err := api.GetStates(sr)
+	o.LogWrnErr(nil, err, true)
+
+	tb := table.NewTable(
+		"DHCPv6 Leases",
+		[]string{
+			"Server Name",
+			"Address",
+			"Valid Time",
+			"Preferred Time",
+			"Renew Time",
+			"Rebind Time",
+			"Client ID",
+			"Remote ID",
+			"Interface ID",
+		},
+		20,
+	)
+
+	for _, v := range res.Dhcpv6Leases().Items() {
+		if v != nil {
+			for _, l := range v.Leases().Items() {
+				tb.AppendRow([]interface{}{
+					v.DhcpServerName(),
+					l.Address(),
+					l.ValidTime(),
+					l.PreferredTime(),
+					l.RenewTime(),
+					l.RebindTime(),
+					l.ClientId(),
+					l.RemoteId(),
+					l.InterfaceId(),
+				})
+			}
+		}
+	}
+
+	t.Log(tb.String())
+	return res.Dhcpv6Leases().Items()
+}
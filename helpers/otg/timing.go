@@ -0,0 +1,271 @@
+package otg
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/open-traffic-generator/conformance/helpers/table"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+// FlowTiming holds expected packet counts and timing of a flow
+type FlowTiming struct {
+	Pps float64
+	// total frames, or 0 when transmitted till stopped
+	Frames uint64
+	// expected transmit duration, or 0 when transmitted till stopped
+	Duration  time.Duration
+	PacketGap time.Duration
+	// 0 unless duration is burst, in which case Bursts may be 0 as well when
+	// bursts are transmitted till stopped
+	Bursts        uint32
+	BurstPackets  uint32
+	InterBurstGap time.Duration
+}
+
+// FlowPps returns packets per second of flow, where rate in bits per second
+// is taken to exclude preamble and inter frame gap while percentage of line
+// speed is taken to include them
+func (o *OtgApi) FlowPps(flow gosnappi.Flow) float64 {
+	avgSize := o.AvgFrameSize(flow.Size())
+	frameBits := avgSize * 8
+	lineBits := (avgSize + 12 + 8) * 8
+
+	r := flow.Rate()
+	switch r.Choice() {
+	case gosnappi.FlowRateChoice.BPS:
+		return float64(r.Bps()) / frameBits
+	case gosnappi.FlowRateChoice.KBPS:
+		return float64(r.Kbps()) * 1e3 / frameBits
+	case gosnappi.FlowRateChoice.MBPS:
+		return float64(r.Mbps()) * 1e6 / frameBits
+	case gosnappi.FlowRateChoice.GBPS:
+		return float64(r.Gbps()) * 1e9 / frameBits
+	case gosnappi.FlowRateChoice.PERCENTAGE:
+		lineSpeedMbps := o.Layer1SpeedToMpbs(o.TestConfig().OtgSpeed)
+		return float64(lineSpeedMbps) * 1e6 * float64(r.Percentage()) / 100 / lineBits
+	default:
+		return float64(r.Pps())
+	}
+}
+
+// ExpectedFlowTiming returns packet counts and timing of flow as per its rate
+// and duration
+func (o *OtgApi) ExpectedFlowTiming(flow gosnappi.Flow) FlowTiming {
+	t := o.Testing()
+
+	ft := FlowTiming{Pps: o.FlowPps(flow)}
+	if ft.Pps <= 0 {
+		t.Fatalf("ERROR: Flow %s has no rate\n", flow.Name())
+	}
+	ft.PacketGap = time.Duration(float64(time.Second) / ft.Pps)
+
+	d := flow.Duration()
+	switch d.Choice() {
+	case gosnappi.FlowDurationChoice.FIXED_PACKETS:
+		ft.Frames = uint64(d.FixedPackets().Packets())
+		ft.Duration = time.Duration(ft.Frames) * ft.PacketGap
+	case gosnappi.FlowDurationChoice.FIXED_SECONDS:
+		ft.Duration = time.Duration(float64(d.FixedSeconds().Seconds()) * float64(time.Second))
+		ft.Frames = uint64(math.Round(float64(d.FixedSeconds().Seconds()) * ft.Pps))
+	case gosnappi.FlowDurationChoice.BURST:
+		b := d.Burst()
+		ft.Bursts = b.Bursts()
+		ft.BurstPackets = b.Packets()
+		lineSpeedMbps := o.Layer1SpeedToMpbs(o.TestConfig().OtgSpeed)
+		// packets in a burst are apart by at least gap bytes following preamble
+		// and frame, which may exceed packet gap as per rate
+		if lineSpeedMbps != 0 {
+			minGap := time.Duration((o.AvgFrameSize(flow.Size()) + 8 + float64(b.Gap())) * 8 * 1000 / float64(lineSpeedMbps))
+			if minGap > ft.PacketGap {
+				ft.PacketGap = minGap
+				ft.Pps = float64(time.Second) / float64(minGap)
+			}
+		}
+		ibg := b.InterBurstGap()
+		switch ibg.Choice() {
+		case gosnappi.FlowDurationInterBurstGapChoice.NANOSECONDS:
+			ft.InterBurstGap = time.Duration(ibg.Nanoseconds())
+		case gosnappi.FlowDurationInterBurstGapChoice.MICROSECONDS:
+			ft.InterBurstGap = time.Duration(ibg.Microseconds() * float64(time.Microsecond))
+		default:
+			if lineSpeedMbps != 0 {
+				ft.InterBurstGap = time.Duration(ibg.Bytes() * 8 * 1000 / float64(lineSpeedMbps))
+			}
+		}
+		if ft.Bursts != 0 {
+			ft.Frames = uint64(ft.Bursts) * uint64(ft.BurstPackets)
+			ft.Duration = time.Duration(ft.Frames)*ft.PacketGap + time.Duration(ft.Bursts-1)*ft.InterBurstGap
+		}
+	}
+
+	return ft
+}
+
+// ValidatePacketTiming validates gaps between timestamps of packets with
+// ethernet source srcMac in capture against expected timing of flow, where
+// average packet gap, inter burst gaps and transmit duration may deviate
+// from expected by fraction tolerance; returns number of validated packets
+func (o *OtgApi) ValidatePacketTiming(cPackets *CapturedPackets, flow gosnappi.Flow, srcMac string, tolerance float64) int {
+	t := o.Testing()
+	ft := o.ExpectedFlowTiming(flow)
+
+	mac := o.MacAddrToBytes(srcMac)
+	stamps := []time.Time{}
+	for i := range cPackets.Packets {
+		if cPackets.HasField(t, "ethernet src", i, 6, mac) {
+			stamps = append(stamps, cPackets.Packets[i].Timestamp)
+		}
+	}
+	if len(stamps) < 2 {
+		t.Fatalf("ERROR: Less than 2 packets with ethernet src %s in capture\n", srcMac)
+	}
+
+	// gaps longer than halfway to inter burst gap mark start of next burst
+	threshold := time.Duration(math.MaxInt64)
+	if ft.BurstPackets != 0 {
+		threshold = ft.PacketGap + ft.InterBurstGap/2
+	}
+
+	bursts := [][]time.Time{{stamps[0]}}
+	ibgs := []time.Duration{}
+	packetGaps := time.Duration(0)
+	for i := 1; i < len(stamps); i++ {
+		gap := stamps[i].Sub(stamps[i-1])
+		if gap > threshold {
+			ibgs = append(ibgs, gap)
+			bursts = append(bursts, []time.Time{})
+		} else {
+			packetGaps += gap
+		}
+		bursts[len(bursts)-1] = append(bursts[len(bursts)-1], stamps[i])
+	}
+	avgGap := ft.PacketGap
+	if len(stamps) > len(bursts) {
+		avgGap = packetGaps / time.Duration(len(stamps)-len(bursts))
+	}
+	span := stamps[len(stamps)-1].Sub(stamps[0])
+
+	tb := table.NewTable(
+		"Packet Timing",
+		[]string{"Packets", "Bursts", "ExpGapUs", "AvgGapUs", "ExpIbgUs", "MinIbgUs", "ExpSpanMs", "SpanMs"},
+		12,
+	)
+	minIbg := time.Duration(0)
+	for i, g := range ibgs {
+		if i == 0 || g < minIbg {
+			minIbg = g
+		}
+	}
+	// first and last packet are apart by one packet gap less than duration
+	expSpan := time.Duration(0)
+	if ft.Duration != 0 {
+		expSpan = ft.Duration - ft.PacketGap
+	}
+	tb.AppendRow([]interface{}{
+		len(stamps),
+		len(bursts),
+		ft.PacketGap.Microseconds(),
+		avgGap.Microseconds(),
+		ft.InterBurstGap.Microseconds(),
+		minIbg.Microseconds(),
+		expSpan.Milliseconds(),
+		span.Milliseconds(),
+	})
+	t.Log(tb.String())
+
+	if math.Abs(float64(avgGap-ft.PacketGap)) > tolerance*float64(ft.PacketGap) {
+		t.Fatalf("ERROR: Average packet gap %v deviates from %v by more than %.2f%%\n", avgGap, ft.PacketGap, tolerance*100)
+	}
+	if expSpan != 0 && math.Abs(float64(span-expSpan)) > tolerance*float64(expSpan) {
+		t.Fatalf("ERROR: Transmit span %v deviates from %v by more than %.2f%%\n", span, expSpan, tolerance*100)
+	}
+
+	if ft.BurstPackets == 0 {
+		return len(stamps)
+	}
+	if ft.Bursts != 0 && len(bursts) != int(ft.Bursts) {
+		t.Fatalf("ERROR: expBursts %d != actBursts %d\n", ft.Bursts, len(bursts))
+	}
+	for i, b := range bursts {
+		// capture may start or stop in the middle of bursts sent till stopped
+		partial := ft.Bursts == 0 && (i == 0 || i == len(bursts)-1)
+		if len(b) != int(ft.BurstPackets) && !partial {
+			t.Fatalf("ERROR: Burst %d has %d packets instead of %d\n", i, len(b), ft.BurstPackets)
+		}
+	}
+	if len(ibgs) != 0 && float64(minIbg) < (1-tolerance)*float64(ft.InterBurstGap) {
+		t.Fatalf("ERROR: Inter burst gap %v is less than %v by more than %.2f%%\n", minIbg, ft.InterBurstGap, tolerance*100)
+	}
+
+	return len(stamps)
+}
+
+// FlowRates holds frames per second sent and received by a flow, both as
+// computed from frame counters and as reported by the implementation
+type FlowRates struct {
+	TxPps         float64
+	RxPps         float64
+	ReportedTxPps float64
+	ReportedRxPps float64
+	// fraction by which counter based rates may deviate from actual rates,
+	// since counters are sampled at unknown time during metrics requests
+	SampleError float64
+}
+
+// MeasureFlowRates returns rates of each flow keyed by flow name, computed
+// from frames sent and received over window
+func (o *OtgApi) MeasureFlowRates(window time.Duration) map[string]FlowRates {
+	t := o.Testing()
+
+	t.Logf("Measuring flow rates over %v ...\n", window)
+	defer o.Timer(time.Now(), "MeasureFlowRates")
+
+	// each snapshot is taken to be sampled halfway through its request
+	start := time.Now()
+	before := map[string]gosnappi.FlowMetric{}
+	for _, m := range o.GetFlowMetrics() {
+		before[m.Name()] = m
+	}
+	startLatency := time.Since(start)
+	time.Sleep(window)
+	end := time.Now()
+	after := o.GetFlowMetrics()
+	endLatency := time.Since(end)
+
+	elapsed := (end.Sub(start) + (endLatency-startLatency)/2).Seconds()
+	sampleError := (startLatency + endLatency).Seconds() / 2 / elapsed
+
+	tb := table.NewTable(
+		"Flow Rates",
+		[]string{"Name", "Tx Pps", "Rx Pps", "Reported Tx Pps", "Reported Rx Pps", "Sample Error %"},
+		15,
+	)
+	rates := map[string]FlowRates{}
+	for _, m := range after {
+		b, ok := before[m.Name()]
+		if !ok {
+			continue
+		}
+		r := FlowRates{
+			TxPps:         float64(m.FramesTx()-b.FramesTx()) / elapsed,
+			RxPps:         float64(m.FramesRx()-b.FramesRx()) / elapsed,
+			ReportedTxPps: float64(m.FramesTxRate()),
+			ReportedRxPps: float64(m.FramesRxRate()),
+			SampleError:   sampleError,
+		}
+		rates[m.Name()] = r
+		tb.AppendRow([]interface{}{
+			m.Name(),
+			int(r.TxPps),
+			int(r.RxPps),
+			int(r.ReportedTxPps),
+			int(r.ReportedRxPps),
+			fmt.Sprintf("%.3f", r.SampleError*100),
+		})
+	}
+	t.Log(tb.String())
+
+	return rates
+}
@@ -1,6 +1,7 @@
 package otg
 
 import (
+	"math"
 	"sort"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 type ThroughputMetric struct {
 	startTime      time.Time
 	txPpsSnapshots []int
+	// average frame size when frames are not of fixed size
+	avgSize float64
 
 	ConfiguredLineSpeedMbps int
 	ConfiguredLineRate      float32
@@ -41,6 +44,22 @@ func NewThroughputMetric(lineSpeedMbps int, lineRate float32, frames uint64, siz
 	return m
 }
 
+// NewAvgSizeThroughputMetric returns metric for frames of varying size (e.g.
+// IMIX) with average size avgSize
+func NewAvgSizeThroughputMetric(lineSpeedMbps int, lineRate float32, frames uint64, avgSize float64) *ThroughputMetric {
+	m := NewThroughputMetric(lineSpeedMbps, lineRate, frames, int(math.Round(avgSize)))
+	m.avgSize = avgSize
+
+	return m
+}
+
+// ExpectedPps returns frames per second at lineRate (percentage of line
+// speed) for frames of average size avgSize, including preamble and minimum
+// inter frame gap
+func ExpectedPps(lineSpeedMbps int, lineRate float32, avgSize float64) int {
+	return int(float64(lineSpeedMbps) * float64(lineRate) * 10000 / ((avgSize + 12 + 8) * 8))
+}
+
 func (m *ThroughputMetric) AddPpsSnapshot(txFrames uint64, txPps int) {
 	m.TxFrames = txFrames
 
@@ -51,6 +70,9 @@ func (m *ThroughputMetric) StartCollecting() {
 	bits := (m.ConfiguredSize + 12 + 8) * 8
 
 	m.ConfiguredPps = (m.ConfiguredLineSpeedMbps * int(m.ConfiguredLineRate) * 10000) / bits
+	if m.avgSize != 0 {
+		m.ConfiguredPps = ExpectedPps(m.ConfiguredLineSpeedMbps, m.ConfiguredLineRate, m.avgSize)
+	}
 	m.ConfiguredDuration = time.Duration((m.ConfiguredFrames * 1000 / uint64(m.ConfiguredPps))) * time.Millisecond
 	m.startTime = time.Now()
 }